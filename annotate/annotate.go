@@ -0,0 +1,221 @@
+// Package annotate recognizes common X.509/PKIX/PKCS/CMS structures in a
+// decoded DER tree and labels them the way `openssl x509 -text` annotates
+// a certificate dump, but driven from der's generic element walker rather
+// than a fixed ASN.1 module for each structure. It's opt-in: der2text's
+// -annotate flag is what asks der.ParseAnnotated to print these labels as
+// "# ..." comments alongside the same text it always produces.
+//
+// Recognition is structural (child count, tag shape) rather than a real
+// ASN.1 parse against each structure's module, so it can be fooled by an
+// unusual but valid encoding; it's meant to label the common case, not to
+// replace a real PKIX decoder.
+package annotate
+
+import (
+	"fmt"
+
+	"github.com/syncsynchalt/der2text/der"
+)
+
+// ASN.1 universal tag numbers and class bytes this package matches
+// against. der keeps its own copies of these unexported, and this package
+// can't reach them; re-declaring the handful it needs is simpler than
+// exporting der's internal tag table just for this.
+const (
+	classUniversal       = 0x00
+	classContextSpecific = 0x80
+
+	tagBoolean         = 0x01
+	tagInteger         = 0x02
+	tagBitString       = 0x03
+	tagOctetString     = 0x04
+	tagOID             = 0x06
+	tagSequence        = 0x10
+	tagSet             = 0x11
+	tagUTCTime         = 0x17
+	tagGeneralizedTime = 0x18
+)
+
+// Annotate inspects elements for recognized structures and returns a
+// lookup from element to the "# ..." comment der.ParseAnnotated should
+// print for it, or "" for elements it doesn't recognize.
+func Annotate(elements []*der.Element) func(*der.Element) string {
+	labels := map[*der.Element]string{}
+	for _, e := range elements {
+		annotateTop(e, labels)
+	}
+	return func(e *der.Element) string { return labels[e] }
+}
+
+func isSeq(e *der.Element) bool {
+	return e.Class == classUniversal && e.Tag == tagSequence && e.Composed
+}
+
+func isTag(e *der.Element, tag uint8) bool {
+	return e.Class == classUniversal && e.Tag == tag && !e.Composed
+}
+
+func annotateTop(e *der.Element, labels map[*der.Element]string) {
+	if !isSeq(e) {
+		return
+	}
+	switch {
+	case looksLikeCertOrCRLOrCSR(e):
+		annotateCertLike(e, labels)
+	case looksLikeContentInfo(e):
+		annotateContentInfo(e, labels)
+	case looksLikePrivateKeyInfo(e):
+		annotatePrivateKeyInfo(e, labels)
+	case looksLikeRSAPrivateKey(e):
+		annotateRSAPrivateKey(e, labels)
+	case looksLikeECPrivateKey(e):
+		annotateECPrivateKey(e, labels)
+	case looksLikeRSAPublicKey(e):
+		annotateRSAPublicKey(e, labels)
+	case looksLikeDHParameter(e):
+		annotateDHParameter(e, labels)
+	}
+}
+
+// looksLikeCertOrCRLOrCSR matches the shape X.509 Certificate,
+// CertificationRequest (PKCS#10), and CertificateList (CRL) all share: a
+// SEQUENCE of { tbs SEQUENCE, signatureAlgorithm SEQUENCE, signature
+// BITSTRING }. classify tells the three apart by what's inside the first
+// field.
+func looksLikeCertOrCRLOrCSR(e *der.Element) bool {
+	return len(e.Children) == 3 &&
+		isSeq(e.Children[0]) &&
+		isSeq(e.Children[1]) &&
+		isTag(e.Children[2], tagBitString)
+}
+
+func annotateCertLike(top *der.Element, labels map[*der.Element]string) {
+	switch classify(top.Children[0]) {
+	case shapeCRL:
+		annotateCRL(top, labels)
+	case shapeCSR:
+		annotateCSR(top, labels)
+	default:
+		annotateCertificate(top, labels)
+	}
+}
+
+type shape int
+
+const (
+	shapeCertificate shape = iota
+	shapeCSR
+	shapeCRL
+)
+
+// classify tells apart a Certificate's tbsCertificate, a
+// CertificationRequest's certificationRequestInfo, and a CertificateList's
+// tbsCertList: tbsCertList's thisUpdate/nextUpdate are bare Time fields
+// directly under it, while tbsCertificate nests its two Time fields inside
+// a "validity" SEQUENCE. Anything with neither shape is taken to be a CSR,
+// whose certificationRequestInfo has no Time field at all.
+func classify(tbs *der.Element) shape {
+	for _, c := range tbs.Children {
+		if isTag(c, tagUTCTime) || isTag(c, tagGeneralizedTime) {
+			return shapeCRL
+		}
+	}
+	for _, c := range tbs.Children {
+		if isSeq(c) && len(c.Children) == 2 &&
+			(isTag(c.Children[0], tagUTCTime) || isTag(c.Children[0], tagGeneralizedTime)) &&
+			(isTag(c.Children[1], tagUTCTime) || isTag(c.Children[1], tagGeneralizedTime)) {
+			return shapeCertificate
+		}
+	}
+	return shapeCSR
+}
+
+func annotateCertificate(top *der.Element, labels map[*der.Element]string) {
+	tbs := top.Children[0]
+	fields := tbs.Children
+	version := 1
+	if len(fields) > 0 && fields[0].Class == classContextSpecific && fields[0].Composed &&
+		fields[0].TagNum == 0 && len(fields[0].Children) == 1 {
+		if n, err := fields[0].Children[0].AsInteger(); err == nil {
+			version = int(n.Int64()) + 1
+		}
+		labels[fields[0]] = "tbsCertificate.version"
+		fields = fields[1:]
+	}
+	labels[top] = fmt.Sprintf("X.509 Certificate v%d", version)
+
+	names := []string{"tbsCertificate.serialNumber", "tbsCertificate.signature",
+		"tbsCertificate.issuer", "tbsCertificate.validity", "tbsCertificate.subject",
+		"tbsCertificate.subjectPublicKeyInfo"}
+	for i, name := range names {
+		if i >= len(fields) {
+			break
+		}
+		labels[fields[i]] = name
+	}
+	if len(fields) >= 4 && len(fields[3].Children) == 2 {
+		labels[fields[3].Children[0]] = "validity.notBefore"
+		labels[fields[3].Children[1]] = "validity.notAfter"
+	}
+	for _, f := range fields[min(len(names), len(fields)):] {
+		switch {
+		case f.Class == classContextSpecific && f.TagNum == 1:
+			labels[f] = "tbsCertificate.issuerUniqueID"
+		case f.Class == classContextSpecific && f.TagNum == 2:
+			labels[f] = "tbsCertificate.subjectUniqueID"
+		case f.Class == classContextSpecific && f.TagNum == 3:
+			annotateExtensions(f, "tbsCertificate.extensions", labels)
+		}
+	}
+
+	labels[top.Children[1]] = "signatureAlgorithm"
+	labels[top.Children[2]] = "signatureValue"
+}
+
+func annotateCSR(top *der.Element, labels map[*der.Element]string) {
+	labels[top] = "PKCS#10 CertificationRequest"
+	info := top.Children[0]
+	names := []string{"certificationRequestInfo.version", "certificationRequestInfo.subject",
+		"certificationRequestInfo.subjectPKInfo"}
+	for i, name := range names {
+		if i < len(info.Children) {
+			labels[info.Children[i]] = name
+		}
+	}
+	if len(info.Children) > len(names) {
+		labels[info.Children[len(names)]] = "certificationRequestInfo.attributes"
+	}
+	labels[top.Children[1]] = "signatureAlgorithm"
+	labels[top.Children[2]] = "signature"
+}
+
+func annotateCRL(top *der.Element, labels map[*der.Element]string) {
+	labels[top] = "X.509 CertificateList (CRL)"
+	fields := top.Children[0].Children
+	i := 0
+	if i < len(fields) && isTag(fields[i], tagInteger) {
+		labels[fields[i]] = "tbsCertList.version"
+		i++
+	}
+	for _, name := range []string{"tbsCertList.signature", "tbsCertList.issuer", "tbsCertList.thisUpdate"} {
+		if i >= len(fields) {
+			break
+		}
+		labels[fields[i]] = name
+		i++
+	}
+	if i < len(fields) && (isTag(fields[i], tagUTCTime) || isTag(fields[i], tagGeneralizedTime)) {
+		labels[fields[i]] = "tbsCertList.nextUpdate"
+		i++
+	}
+	if i < len(fields) && isSeq(fields[i]) {
+		labels[fields[i]] = "tbsCertList.revokedCertificates"
+		i++
+	}
+	if i < len(fields) && fields[i].Class == classContextSpecific && fields[i].TagNum == 0 {
+		annotateExtensions(fields[i], "tbsCertList.crlExtensions", labels)
+	}
+
+	labels[top.Children[1]] = "signatureAlgorithm"
+	labels[top.Children[2]] = "signatureValue"
+}
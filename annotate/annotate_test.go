@@ -0,0 +1,99 @@
+package annotate
+
+import (
+	"testing"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/test"
+)
+
+var (
+	emptySeq  = []byte{0x30, 0x00}
+	intZero   = []byte{0x02, 0x01, 0x00}
+	bitString = []byte{0x03, 0x01, 0x00}
+	utcTime   = []byte{0x17, 0x0D, '2', '6', '0', '1', '0', '1', '0', '0', '0', '0', '0', '0', 'Z'}
+)
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func wrap(tag byte, content []byte) []byte {
+	return concat([]byte{tag, byte(len(content))}, content)
+}
+
+func TestAnnotateCertificateVsCSRVsCRL(t *testing.T) {
+	validity := wrap(0x30, concat(utcTime, utcTime))
+	tbsCert := wrap(0x30, concat(intZero, emptySeq, emptySeq, validity))
+	cert := wrap(0x30, concat(tbsCert, emptySeq, bitString))
+
+	tbsCSR := wrap(0x30, concat(intZero, emptySeq, emptySeq))
+	csr := wrap(0x30, concat(tbsCSR, emptySeq, bitString))
+
+	tbsCRL := wrap(0x30, concat(intZero, emptySeq, emptySeq, utcTime))
+	crl := wrap(0x30, concat(tbsCRL, emptySeq, bitString))
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"certificate", cert, "X.509 Certificate v1"},
+		{"csr", csr, "PKCS#10 CertificationRequest"},
+		{"crl", crl, "X.509 CertificateList (CRL)"},
+	} {
+		elements, err := der.Decode(tc.data)
+		test.Ok(t, err)
+		labelFor := Annotate(elements)
+		test.Equals(t, tc.want, labelFor(elements[0]))
+	}
+}
+
+func TestAnnotateRSAPrivateKey(t *testing.T) {
+	data := wrap(0x30, concat(intZero, intZero, intZero, intZero, intZero, intZero, intZero, intZero, intZero))
+	elements, err := der.Decode(data)
+	test.Ok(t, err)
+	labelFor := Annotate(elements)
+	test.Equals(t, "RSAPrivateKey", labelFor(elements[0]))
+	test.Equals(t, "modulus", labelFor(elements[0].Children[1]))
+	test.Equals(t, "coefficient", labelFor(elements[0].Children[8]))
+}
+
+func TestAnnotateRSAPublicKeyNotMistakenForDHParameter(t *testing.T) {
+	modulus := make([]byte, 65) // well over the RSA-modulus-size threshold
+	modulus[1] = 0x01
+	exponent := []byte{0x01, 0x00, 0x01} // 65537
+
+	rsaPub := wrap(0x30, concat(wrap(0x02, modulus), wrap(0x02, exponent)))
+	elements, err := der.Decode(rsaPub)
+	test.Ok(t, err)
+	labelFor := Annotate(elements)
+	test.Equals(t, "RSAPublicKey", labelFor(elements[0]))
+	test.Equals(t, "publicExponent", labelFor(elements[0].Children[1]))
+
+	dh := wrap(0x30, concat(wrap(0x02, modulus), wrap(0x02, []byte{0x02})))
+	elements, err = der.Decode(dh)
+	test.Ok(t, err)
+	labelFor = Annotate(elements)
+	test.Equals(t, "DHParameter", labelFor(elements[0]))
+}
+
+func TestAnnotateExtensionsSummarizesKeyUsage(t *testing.T) {
+	// extnID 2.5.29.15 (keyUsage), extnValue OCTETSTRING wrapping a
+	// BITSTRING with only the digitalSignature bit set.
+	keyUsageOID := []byte{0x06, 0x03, 0x55, 0x1D, 0x0F}
+	keyUsageValue := wrap(0x04, []byte{0x03, 0x02, 0x00, 0x80})
+	extension := wrap(0x30, concat(keyUsageOID, keyUsageValue))
+	extensions := wrap(0x30, extension)
+	wrapper := wrap(0xA3, extensions)
+
+	elements, err := der.Decode(wrapper)
+	test.Ok(t, err)
+	labels := map[*der.Element]string{}
+	annotateExtensions(elements[0], "tbsCertificate.extensions", labels)
+	test.Equals(t, "tbsCertificate.extensions: keyUsage: digitalSignature", labels[elements[0]])
+}
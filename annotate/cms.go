@@ -0,0 +1,61 @@
+package annotate
+
+import (
+	"fmt"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/oids"
+)
+
+// looksLikeContentInfo matches CMS's ContentInfo: SEQUENCE { contentType
+// OID, content [0] EXPLICIT ANY DEFINED BY contentType }.
+func looksLikeContentInfo(e *der.Element) bool {
+	return len(e.Children) == 2 &&
+		isTag(e.Children[0], tagOID) &&
+		e.Children[1].Class == classContextSpecific && e.Children[1].Composed && e.Children[1].TagNum == 0
+}
+
+func annotateContentInfo(e *der.Element, labels map[*der.Element]string) {
+	oid, err := e.Children[0].AsOID()
+	if err != nil {
+		return
+	}
+	name := oids.Name(oid)
+	if name == "" {
+		name = oid
+	}
+	labels[e] = fmt.Sprintf("CMS ContentInfo: %s", name)
+	labels[e.Children[0]] = "contentType"
+	labels[e.Children[1]] = "content"
+
+	if oid == oids.OID("signedData") && len(e.Children[1].Children) == 1 {
+		if summary, ok := summarizeSignedData(e.Children[1].Children[0]); ok {
+			labels[e.Children[1]] = "content: " + summary
+		}
+	}
+}
+
+// summarizeSignedData decodes CMS's SignedData: SEQUENCE { version INTEGER,
+// digestAlgorithms SET, encapContentInfo SEQUENCE, certificates [0]
+// OPTIONAL, crls [1] OPTIONAL, signerInfos SET } into a one-line summary.
+// Like annotateExtensions, it can't label SignedData's fields directly —
+// der's printer treats the [0] EXPLICIT "content" wrapper they live inside
+// as an opaque hex blob, so those fields never appear as lines of their
+// own — but it can still count them by reading the already-decoded tree.
+func summarizeSignedData(sd *der.Element) (string, bool) {
+	if !isSeq(sd) || len(sd.Children) < 4 {
+		return "", false
+	}
+	var nCerts, nCRLs, nSigners int
+	for _, f := range sd.Children[3:] {
+		switch {
+		case f.Class == classContextSpecific && f.TagNum == 0:
+			nCerts = len(f.Children)
+		case f.Class == classContextSpecific && f.TagNum == 1:
+			nCRLs = len(f.Children)
+		case f.Class == classUniversal && f.Tag == tagSet && f.Composed:
+			nSigners = len(f.Children)
+		}
+	}
+	return fmt.Sprintf("SignedData, %d cert(s), %d signerInfo(s), %d crl(s)", nCerts, nSigners, nCRLs), true
+}
@@ -0,0 +1,195 @@
+package annotate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/oids"
+)
+
+// annotateExtensions labels a certificate's or CRL's extensions field — a
+// [0]/[1] EXPLICIT wrapper around a SEQUENCE OF Extension, where each
+// Extension is SEQUENCE { extnID OID, critical BOOLEAN OPTIONAL, extnValue
+// OCTETSTRING }. der's text printer never descends into a short-form
+// context-specific composed tag (it always prints that shape as an opaque
+// hex blob, annotate or not), so the per-Extension fields never appear as
+// lines of their own to attach a label to; instead this builds one
+// semicolon-separated summary — extension name, and for the extensions
+// this package recognizes, a decode of extnValue's nested DER — and sets
+// it as prefix's label on wrapper itself, the one element the printer does
+// visit.
+func annotateExtensions(wrapper *der.Element, prefix string, labels map[*der.Element]string) {
+	labels[wrapper] = prefix
+	if len(wrapper.Children) != 1 || !isSeq(wrapper.Children[0]) {
+		return
+	}
+	var parts []string
+	for _, ext := range wrapper.Children[0].Children {
+		if !isSeq(ext) || len(ext.Children) < 2 {
+			continue
+		}
+		oid, err := ext.Children[0].AsOID()
+		if err != nil {
+			continue
+		}
+		name := oids.Name(oid)
+		if name == "" {
+			name = oid
+		}
+		valueEl := ext.Children[len(ext.Children)-1]
+		if summary, ok := summarizeExtensionValue(oid, valueEl.Raw); ok {
+			name += ": " + summary
+		}
+		parts = append(parts, name)
+	}
+	if len(parts) > 0 {
+		labels[wrapper] = prefix + ": " + strings.Join(parts, "; ")
+	}
+}
+
+func summarizeExtensionValue(oid string, raw []byte) (string, bool) {
+	switch oid {
+	case oids.OID("subjectAltName"):
+		return summarizeSubjectAltName(raw)
+	case oids.OID("keyUsage"):
+		return summarizeKeyUsage(raw)
+	case oids.OID("basicConstraints"):
+		return summarizeBasicConstraints(raw)
+	case oids.OID("cRLDistributionPoints"):
+		return summarizeCRLDistributionPoints(raw)
+	}
+	return "", false
+}
+
+// summarizeSubjectAltName decodes a GeneralNames SEQUENCE OF GeneralName
+// and renders the entry kinds der2text's raw dump doesn't otherwise
+// distinguish (rfc822Name, dNSName, URI, iPAddress).
+func summarizeSubjectAltName(raw []byte) (string, bool) {
+	elements, err := der.Decode(raw)
+	if err != nil || len(elements) != 1 || !isSeq(elements[0]) {
+		return "", false
+	}
+	var parts []string
+	for _, gn := range elements[0].Children {
+		if gn.Class != classContextSpecific {
+			continue
+		}
+		switch gn.TagNum {
+		case 1:
+			parts = append(parts, "email:"+string(gn.Raw))
+		case 2:
+			parts = append(parts, "DNS:"+string(gn.Raw))
+		case 6:
+			parts = append(parts, "URI:"+string(gn.Raw))
+		case 7:
+			parts = append(parts, "IP:"+formatIP(gn.Raw))
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, ", "), true
+}
+
+func formatIP(b []byte) string {
+	if ip := net.IP(b); len(b) == 4 || len(b) == 16 {
+		return ip.String()
+	}
+	return fmt.Sprintf("% x", b)
+}
+
+var keyUsageBits = []string{
+	"digitalSignature", "nonRepudiation", "keyEncipherment", "dataEncipherment",
+	"keyAgreement", "keyCertSign", "cRLSign", "encipherOnly", "decipherOnly",
+}
+
+// summarizeKeyUsage decodes keyUsage's nested BIT STRING and names the set
+// bits instead of leaving them as an opaque bitmask.
+func summarizeKeyUsage(raw []byte) (string, bool) {
+	elements, err := der.Decode(raw)
+	if err != nil || len(elements) != 1 || elements[0].Tag != tagBitString || len(elements[0].Raw) < 1 {
+		return "", false
+	}
+	content := elements[0].Raw
+	var set []string
+	for i, name := range keyUsageBits {
+		byteIdx, bitIdx := 1+i/8, 7-i%8
+		if byteIdx >= len(content) {
+			break
+		}
+		if content[byteIdx]&(1<<uint(bitIdx)) != 0 {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 0 {
+		return "", false
+	}
+	return strings.Join(set, ", "), true
+}
+
+// summarizeBasicConstraints decodes basicConstraints' nested SEQUENCE {
+// cA BOOLEAN DEFAULT FALSE, pathLenConstraint INTEGER OPTIONAL } into the
+// "CA:TRUE, pathlen:N" form openssl prints.
+func summarizeBasicConstraints(raw []byte) (string, bool) {
+	elements, err := der.Decode(raw)
+	if err != nil || len(elements) != 1 || !isSeq(elements[0]) {
+		return "", false
+	}
+	ca := false
+	pathLen := -1
+	for _, c := range elements[0].Children {
+		switch {
+		case isTag(c, tagBoolean):
+			ca = len(c.Raw) == 1 && c.Raw[0] != 0
+		case isTag(c, tagInteger):
+			if n, err := c.AsInteger(); err == nil {
+				pathLen = int(n.Int64())
+			}
+		}
+	}
+	summary := "CA:FALSE"
+	if ca {
+		summary = "CA:TRUE"
+	}
+	if pathLen >= 0 {
+		summary += fmt.Sprintf(", pathlen:%d", pathLen)
+	}
+	return summary, true
+}
+
+// summarizeCRLDistributionPoints decodes cRLDistributionPoints' nested
+// SEQUENCE OF DistributionPoint and pulls out the URIs naming each CRL's
+// location, which is almost always all a reader wants from this extension.
+func summarizeCRLDistributionPoints(raw []byte) (string, bool) {
+	elements, err := der.Decode(raw)
+	if err != nil || len(elements) != 1 || !isSeq(elements[0]) {
+		return "", false
+	}
+	var uris []string
+	for _, dp := range elements[0].Children {
+		if !isSeq(dp) {
+			continue
+		}
+		for _, field := range dp.Children {
+			if field.Class != classContextSpecific || field.TagNum != 0 || !field.Composed {
+				continue
+			}
+			for _, name := range field.Children {
+				if name.Class != classContextSpecific || name.TagNum != 0 || !name.Composed {
+					continue
+				}
+				for _, gn := range name.Children {
+					if gn.Class == classContextSpecific && gn.TagNum == 6 {
+						uris = append(uris, string(gn.Raw))
+					}
+				}
+			}
+		}
+	}
+	if len(uris) == 0 {
+		return "", false
+	}
+	return strings.Join(uris, ", "), true
+}
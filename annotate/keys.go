@@ -0,0 +1,115 @@
+package annotate
+
+import "github.com/syncsynchalt/der2text/der"
+
+// looksLikePrivateKeyInfo matches PKCS#8's PrivateKeyInfo: SEQUENCE {
+// version INTEGER, privateKeyAlgorithm SEQUENCE, privateKey OCTETSTRING,
+// attributes [0] OPTIONAL }.
+func looksLikePrivateKeyInfo(e *der.Element) bool {
+	return len(e.Children) >= 3 &&
+		isTag(e.Children[0], tagInteger) &&
+		isSeq(e.Children[1]) &&
+		isTag(e.Children[2], tagOctetString)
+}
+
+func annotatePrivateKeyInfo(e *der.Element, labels map[*der.Element]string) {
+	labels[e] = "PKCS#8 PrivateKeyInfo"
+	labels[e.Children[0]] = "version"
+	labels[e.Children[1]] = "privateKeyAlgorithm"
+	labels[e.Children[2]] = "privateKey"
+}
+
+// looksLikeRSAPrivateKey matches PKCS#1's RSAPrivateKey: nine INTEGER
+// fields (version, modulus, publicExponent, privateExponent, the two
+// primes, the two CRT exponents, and the CRT coefficient).
+func looksLikeRSAPrivateKey(e *der.Element) bool {
+	if len(e.Children) != 9 {
+		return false
+	}
+	for _, c := range e.Children {
+		if !isTag(c, tagInteger) {
+			return false
+		}
+	}
+	return true
+}
+
+func annotateRSAPrivateKey(e *der.Element, labels map[*der.Element]string) {
+	labels[e] = "RSAPrivateKey"
+	names := []string{"version", "modulus", "publicExponent", "privateExponent",
+		"prime1", "prime2", "exponent1", "exponent2", "coefficient"}
+	for i, name := range names {
+		labels[e.Children[i]] = name
+	}
+}
+
+// looksLikeECPrivateKey matches SEC1/RFC 5915's ECPrivateKey: SEQUENCE {
+// version INTEGER(1), privateKey OCTETSTRING, parameters [0] OPTIONAL,
+// publicKey [1] OPTIONAL }.
+func looksLikeECPrivateKey(e *der.Element) bool {
+	if len(e.Children) < 2 || len(e.Children) > 4 {
+		return false
+	}
+	if !isTag(e.Children[0], tagInteger) || !isTag(e.Children[1], tagOctetString) {
+		return false
+	}
+	n, err := e.Children[0].AsInteger()
+	return err == nil && n.Int64() == 1
+}
+
+func annotateECPrivateKey(e *der.Element, labels map[*der.Element]string) {
+	labels[e] = "ECPrivateKey"
+	labels[e.Children[0]] = "version"
+	labels[e.Children[1]] = "privateKey"
+	for _, f := range e.Children[2:] {
+		switch {
+		case f.Class == classContextSpecific && f.TagNum == 0:
+			labels[f] = "parameters"
+		case f.Class == classContextSpecific && f.TagNum == 1:
+			labels[f] = "publicKey"
+		}
+	}
+}
+
+// looksLikeRSAPublicKey matches PKCS#1's RSAPublicKey: SEQUENCE { modulus
+// INTEGER, publicExponent INTEGER } — the same two-INTEGER shape as
+// PKCS#3's DHParameter without its optional privateValueLength, so
+// annotateTop checks this first and disambiguates by size: an RSA modulus
+// is much larger than its exponent, while DH's prime and base aren't
+// reliably different sizes from each other.
+func looksLikeRSAPublicKey(e *der.Element) bool {
+	if len(e.Children) != 2 || !isTag(e.Children[0], tagInteger) || !isTag(e.Children[1], tagInteger) {
+		return false
+	}
+	modulus, exponent := e.Children[0].Raw, e.Children[1].Raw
+	return len(modulus) > 64 && len(exponent) > 0 && len(exponent) <= 8 && exponent[len(exponent)-1]&1 == 1
+}
+
+func annotateRSAPublicKey(e *der.Element, labels map[*der.Element]string) {
+	labels[e] = "RSAPublicKey"
+	labels[e.Children[0]] = "modulus"
+	labels[e.Children[1]] = "publicExponent"
+}
+
+// looksLikeDHParameter matches PKCS#3's DHParameter: SEQUENCE { prime
+// INTEGER, base INTEGER, privateValueLength INTEGER OPTIONAL }.
+func looksLikeDHParameter(e *der.Element) bool {
+	if len(e.Children) != 2 && len(e.Children) != 3 {
+		return false
+	}
+	for _, c := range e.Children {
+		if !isTag(c, tagInteger) {
+			return false
+		}
+	}
+	return true
+}
+
+func annotateDHParameter(e *der.Element, labels map[*der.Element]string) {
+	labels[e] = "DHParameter"
+	labels[e.Children[0]] = "prime"
+	labels[e.Children[1]] = "base"
+	if len(e.Children) == 3 {
+		labels[e.Children[2]] = "privateValueLength"
+	}
+}
@@ -0,0 +1,75 @@
+// Command der2text decodes DER/BER-encoded data and prints its structure,
+// as colorized indented text by default or as JSON/YAML for pipelines.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/syncsynchalt/der2text/annotate"
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/format"
+	"github.com/syncsynchalt/der2text/indenter"
+)
+
+func main() {
+	formatFlag := flag.String("format", "text", "output format: text, json, or yaml")
+	annotateFlag := flag.Bool("annotate", false, "label recognized X.509/PKIX/PKCS/CMS structures (text format only)")
+	flag.Parse()
+
+	in := os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "der2text:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "der2text:", err)
+		os.Exit(1)
+	}
+
+	switch *formatFlag {
+	case "text":
+		if *annotateFlag {
+			err = parseAnnotated(data)
+		} else {
+			err = der.Parse(indenter.New(os.Stdout), data)
+		}
+	case "json":
+		err = decodeAndWrite(data, format.WriteJSON)
+	case "yaml":
+		err = decodeAndWrite(data, format.WriteYAML)
+	default:
+		fmt.Fprintf(os.Stderr, "der2text: unknown -format %q (want text, json, or yaml)\n", *formatFlag)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "der2text:", err)
+		os.Exit(1)
+	}
+}
+
+func decodeAndWrite(data []byte, write func(io.Writer, []*der.Element) error) error {
+	elements, err := der.Decode(data)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, elements)
+}
+
+func parseAnnotated(data []byte) error {
+	elements, err := der.Decode(data)
+	if err != nil {
+		return err
+	}
+	labelFor := annotate.Annotate(elements)
+	return der.WriteText(indenter.New(os.Stdout), elements, labelFor)
+}
@@ -0,0 +1,34 @@
+// Command text2der reads der2text's indented grammar from stdin (or a file
+// named on the command line) and writes the DER/BER bytes it describes to
+// stdout.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/syncsynchalt/der2text/text2der"
+)
+
+func main() {
+	in := os.Stdin
+	if len(os.Args) > 1 {
+		f, err := os.Open(os.Args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "text2der:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	der, err := text2der.Marshal(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "text2der:", err)
+		os.Exit(1)
+	}
+	if _, err := os.Stdout.Write(der); err != nil {
+		fmt.Fprintln(os.Stderr, "text2der:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,284 @@
+package der
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Element is a single decoded DER/BER TLV. Composed elements (SEQUENCE, SET,
+// and anything else with the constructed bit set) have their content fully
+// decoded into Children; Raw still holds the element's raw content bytes for
+// callers that want them directly.
+//
+// Tag and TagNum agree for every tag in the 0-30 range. When a tag uses the
+// BER long-form high-tag-number encoding, Tag is typeIsLongFormTag (0x1F)
+// and TagNum holds the full decoded tag number.
+type Element struct {
+	Class      uint8
+	Tag        uint8
+	TagNum     int
+	Composed   bool
+	Indefinite bool
+	Raw        []byte
+	Children   []*Element
+}
+
+// Decode parses data as a sequence of top-level DER/BER elements.
+func Decode(data []byte) ([]*Element, error) {
+	var elements []*Element
+	for len(data) > 0 {
+		el, rest, err := decodeElement(data)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, el)
+		data = rest
+	}
+	return elements, nil
+}
+
+func decodeElement(data []byte) (el *Element, rest []byte, err error) {
+	if len(data) < 2 {
+		return nil, nil, errors.New("short DER read, need at least two bytes, got " + strconv.Itoa(len(data)))
+	}
+
+	typeByte := data[0]
+	el = &Element{
+		Class:    typeByte & 0xC0,
+		Tag:      typeByte & 0x1F,
+		Composed: typeByte&0x20 == composed,
+	}
+	el.TagNum = int(el.Tag)
+	rest = data[1:]
+
+	if el.Tag == typeIsLongFormTag {
+		el.TagNum, rest, err = decodeLongFormTag(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(rest) < 1 {
+		return nil, nil, errors.New("short DER read, missing length byte")
+	}
+	contentLen, rest, err := decodeLength(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if contentLen == lengthIndefinite {
+		if !el.Composed {
+			return nil, nil, errors.New("Indefinite length not allowed on primitive types")
+		}
+		el.Indefinite = true
+		el.Raw, el.Children, rest, err = decodeIndefiniteChildren(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		return el, rest, nil
+	}
+
+	if len(rest) < contentLen {
+		return nil, nil, errors.New("Short content, need " + strconv.Itoa(contentLen) +
+			" bytes but have " + strconv.Itoa(len(rest)))
+	}
+	el.Raw = rest[:contentLen]
+	rest = rest[contentLen:]
+
+	if el.Composed {
+		el.Children, err = Decode(el.Raw)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return el, rest, nil
+}
+
+// decodeIndefiniteChildren decodes elements from data until it reaches the
+// END-OF-CONTENT marker (00 00) that closes a BER indefinite-length
+// constructed value. It returns the raw bytes spanned by the children (not
+// including the terminator), the decoded children, and the data following
+// the terminator.
+func decodeIndefiniteChildren(data []byte) (content []byte, children []*Element, rest []byte, err error) {
+	for {
+		if len(data) < 2 {
+			return nil, nil, nil, errors.New("short DER read, missing end-of-content marker")
+		}
+		if data[0] == typeEndOfContent|primitive && data[1] == 0 {
+			return content, children, data[2:], nil
+		}
+		var el *Element
+		el, rest, err = decodeElement(data)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		content = append(content, data[:len(data)-len(rest)]...)
+		children = append(children, el)
+		data = rest
+	}
+}
+
+func decodeLength(data []byte) (length int, rest []byte, err error) {
+	firstByte := data[0]
+	if firstByte == 0x80 {
+		return lengthIndefinite, data[1:], nil
+	}
+	if firstByte&0x80 != 0 {
+		numToRead := int(firstByte ^ 0x80)
+		if len(data)-1 < numToRead {
+			return 0, []byte{}, errors.New("Can't satisfy request to read " +
+				strconv.Itoa(numToRead) + " bytes to get length")
+		}
+		length := 0
+		for i := 0; i < numToRead; i++ {
+			length *= 256
+			length += int(data[1+i])
+		}
+		return length, data[1+numToRead:], nil
+	} else {
+		return int(firstByte), data[1:], nil
+	}
+}
+
+// decodeLongFormTag reads the base-128 high-tag-number form that follows a
+// tag byte whose low 5 bits are all set (typeIsLongFormTag). Each byte
+// contributes 7 bits of the tag number, continuing while the high bit is set.
+func decodeLongFormTag(data []byte) (tagNum int, rest []byte, err error) {
+	for i, v := range data {
+		tagNum = tagNum<<7 | int(v&0x7f)
+		if v&0x80 == 0 {
+			return tagNum, data[i+1:], nil
+		}
+	}
+	return 0, nil, errors.New("short DER read, unterminated long-form tag")
+}
+
+// Visitor is implemented by callers that want to walk a decoded Element
+// tree. Enter is called before a composed element's children (if any);
+// returning descend=false skips them, which der's own text printer uses for
+// the composed types (EXTERNAL, EMBEDDED-PDV) it renders as raw data rather
+// than recursing into.
+type Visitor interface {
+	Enter(e *Element) (descend bool, err error)
+	Leave(e *Element) error
+}
+
+// Walk visits every element in elements, and recurses into an element's
+// Children whenever its Visitor's Enter call asks to descend.
+func Walk(elements []*Element, v Visitor) error {
+	for _, e := range elements {
+		descend, err := v.Enter(e)
+		if err != nil {
+			return err
+		}
+		if descend {
+			if err := Walk(e.Children, v); err != nil {
+				return err
+			}
+		}
+		if err := v.Leave(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsOID returns an OID or relative OID element's dotted-decimal form.
+func (e *Element) AsOID() (string, error) {
+	if e.Class != classUniversal || (e.Tag != typeObjectIdentifier && e.Tag != typeRelativeOID) {
+		return "", fmt.Errorf("der: element is not an OID (class=%#x tag=%#x)", e.Class, e.Tag)
+	}
+	if len(e.Raw) < 1 {
+		return "", errors.New("der: OID has no content")
+	}
+
+	arcs := e.Raw
+	oid := ""
+	if e.Tag == typeObjectIdentifier {
+		oid = fmt.Sprintf("%d.%d", arcs[0]/40, arcs[0]%40)
+		arcs = arcs[1:]
+	}
+	var build int
+	for _, v := range arcs {
+		build = build*128 + int(v&0x7f)
+		if v&0x80 == 0 {
+			oid += fmt.Sprintf(".%d", build)
+			build = 0
+		}
+	}
+	if e.Tag == typeRelativeOID {
+		oid = strings.TrimPrefix(oid, ".")
+	}
+	return oid, nil
+}
+
+// AsInteger returns an INTEGER or ENUMERATED element's value.
+func (e *Element) AsInteger() (*big.Int, error) {
+	if e.Class != classUniversal || (e.Tag != typeInteger && e.Tag != typeEnumerated) {
+		return nil, fmt.Errorf("der: element is not an INTEGER (class=%#x tag=%#x)", e.Class, e.Tag)
+	}
+	if len(e.Raw) == 0 {
+		return nil, errors.New("der: INTEGER has no content")
+	}
+	return twosComplementBigInt(e.Raw), nil
+}
+
+// twosComplementBigInt decodes b as a minimal big-endian two's-complement
+// integer, the representation DER/BER use for both INTEGER content and a
+// REAL's binary-encoding exponent.
+func twosComplementBigInt(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return n
+}
+
+// AsUTF8 returns a string element's content as UTF-8, converting from
+// UTF-32BE or UTF-16BE first for UNIVERSALSTRING/BMPSTRING.
+func (e *Element) AsUTF8() (string, error) {
+	if e.Composed {
+		return "", errors.New("der: AsUTF8 called on a composed element")
+	}
+	switch {
+	case e.Class == classUniversal && e.Tag == typeUniversalString:
+		b, err := utf32ToUtf8(e.Raw)
+		return string(b), err
+	case e.Class == classUniversal && e.Tag == typeBMPString:
+		b, err := utf16ToUtf8(e.Raw)
+		return string(b), err
+	default:
+		return string(e.Raw), nil
+	}
+}
+
+// AsTime returns a UTCTime or GeneralizedTime element's value. Only the
+// Zulu-suffixed forms are supported, matching the only forms der's text
+// printer recognizes well enough to annotate.
+func (e *Element) AsTime() (time.Time, error) {
+	if e.Class != classUniversal {
+		return time.Time{}, fmt.Errorf("der: element is not a time (class=%#x tag=%#x)", e.Class, e.Tag)
+	}
+	s := string(e.Raw)
+	switch e.Tag {
+	case typeUTCTime:
+		switch {
+		case len(s) == 13 && strings.HasSuffix(s, "Z"):
+			return time.Parse("060102150405Z", s)
+		case len(s) == 11 && strings.HasSuffix(s, "Z"):
+			return time.Parse("0601021504Z", s)
+		}
+	case typeGeneralizedTime:
+		if strings.HasSuffix(s, "Z") {
+			return time.Parse("20060102150405Z", s)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("der: element tag %#x is not a time type", e.Tag)
+	}
+	return time.Time{}, fmt.Errorf("der: unsupported time format %q", s)
+}
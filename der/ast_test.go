@@ -0,0 +1,47 @@
+package der
+
+import (
+	"testing"
+
+	"github.com/syncsynchalt/der2text/test"
+)
+
+func TestDecodeSequence(t *testing.T) {
+	// SEQUENCE { INTEGER 1, OID 1.2.840.113549.1.1.11 }
+	data := []byte{
+		0x30, 0x0e,
+		0x02, 0x01, 0x01,
+		0x06, 0x09, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x01, 0x0b,
+	}
+	elements, err := Decode(data)
+	test.Ok(t, err)
+	test.Equals(t, 1, len(elements))
+
+	seq := elements[0]
+	test.Equals(t, true, seq.Composed)
+	test.Equals(t, uint8(typeSequence), seq.Tag)
+	test.Equals(t, 2, len(seq.Children))
+
+	n, err := seq.Children[0].AsInteger()
+	test.Ok(t, err)
+	test.Equals(t, int64(1), n.Int64())
+
+	oid, err := seq.Children[1].AsOID()
+	test.Ok(t, err)
+	test.Equals(t, "1.2.840.113549.1.1.11", oid)
+}
+
+func TestAsIntegerNegative(t *testing.T) {
+	el := &Element{Class: classUniversal, Tag: typeInteger, Raw: []byte{0xFF, 0x01}}
+	n, err := el.AsInteger()
+	test.Ok(t, err)
+	test.Equals(t, int64(-255), n.Int64())
+}
+
+func TestAsUTF8RejectsComposed(t *testing.T) {
+	el := &Element{Class: classUniversal, Tag: typeUtf8String, Composed: true}
+	_, err := el.AsUTF8()
+	if err == nil {
+		t.Fatal("expected error for composed element")
+	}
+}
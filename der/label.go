@@ -0,0 +1,97 @@
+package der
+
+import "fmt"
+
+// ClassName returns the lowercase class name ("universal", "application",
+// "context-specific", "private") used by der2text's structured output
+// formats.
+func (e *Element) ClassName() string {
+	switch e.Class {
+	case classUniversal:
+		return "universal"
+	case classApplication:
+		return "application"
+	case classContextSpecific:
+		return "context-specific"
+	case classPrivate:
+		return "private"
+	default:
+		return fmt.Sprintf("unknown(%#x)", e.Class)
+	}
+}
+
+// TagName returns the canonical tag label der2text uses across its text,
+// JSON, and YAML output (e.g. "SEQUENCE", "INTEGER", "OID"). A long-form tag
+// is named "TAG=n"; any other tag der2text doesn't specifically recognize
+// (including every fixed non-universal-class tag, matching the text
+// printer's fallback) is named "UNHANDLED-TAG=xx".
+func (e *Element) TagName() string {
+	if e.Tag == typeIsLongFormTag {
+		return fmt.Sprintf("TAG=%d", e.TagNum)
+	}
+	if e.Class != classUniversal {
+		return fmt.Sprintf("UNHANDLED-TAG=%02x", e.Tag)
+	}
+	switch e.Tag {
+	case typeEndOfContent:
+		return "END-OF-CONTENT"
+	case typeBoolean:
+		return "BOOLEAN"
+	case typeInteger:
+		return "INTEGER"
+	case typeBitString:
+		return "BITSTRING"
+	case typeOctetString:
+		return "OCTETSTRING"
+	case typeNull:
+		return "NULL"
+	case typeObjectIdentifier:
+		return "OID"
+	case typeObjectDescription:
+		return "OBJECTDESCRIPTION"
+	case typeExternal:
+		return "EXTERNAL"
+	case typeReal:
+		return "REAL"
+	case typeEnumerated:
+		return "ENUMERATED"
+	case typeEmbeddedPDV:
+		return "EMBEDDED-PDV"
+	case typeUtf8String:
+		return "UTF8STRING"
+	case typeRelativeOID:
+		return "RELATIVEOID"
+	case typeSequence:
+		return "SEQUENCE"
+	case typeSet:
+		return "SET"
+	case typeNumericString:
+		return "NUMERICSTRING"
+	case typePrintableString:
+		return "PRINTABLESTRING"
+	case typeT61String:
+		return "T61STRING"
+	case typeVideotexString:
+		return "VIDEOTEXSTRING"
+	case typeIA5String:
+		return "IA5STRING"
+	case typeUTCTime:
+		return "UTCTIME"
+	case typeGeneralizedTime:
+		return "GENERALIZEDTIME"
+	case typeGraphicString:
+		return "GRAPHICSTRING"
+	case typeVisibleString:
+		return "VISIBLESTRING"
+	case typeGeneralString:
+		return "GENERALSTRING"
+	case typeUniversalString:
+		return "UNIVERSALSTRING"
+	case typeCharacterString:
+		return "CHARACTERSTRING"
+	case typeBMPString:
+		return "BMPSTRING"
+	default:
+		return fmt.Sprintf("UNHANDLED-TAG=%02x", e.Tag)
+	}
+}
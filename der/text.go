@@ -0,0 +1,459 @@
+package der
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/syncsynchalt/der2text/hinter"
+	"github.com/syncsynchalt/der2text/indenter"
+	"github.com/syncsynchalt/der2text/oids"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// textVisitor is the Visitor that drives Parse's indented-text output. It
+// keeps one *indenter.Indenter per nesting level, pushing a new one (via
+// NextLevel) whenever it descends into a composed element's children.
+type textVisitor struct {
+	stack    []*indenter.Indenter
+	labelFor func(*Element) string
+}
+
+func newTextVisitor(out *indenter.Indenter, labelFor func(*Element) string) *textVisitor {
+	return &textVisitor{stack: []*indenter.Indenter{out}, labelFor: labelFor}
+}
+
+func (v *textVisitor) cur() *indenter.Indenter {
+	return v.stack[len(v.stack)-1]
+}
+
+func (v *textVisitor) Enter(e *Element) (descend bool, err error) {
+	out := v.cur()
+
+	switch e.Class {
+	case classUniversal:
+		out.Print("UNIVERSAL ")
+	case classApplication:
+		out.Print("APPLICATION ")
+	case classContextSpecific:
+		out.Print("CONTEXT-SPECIFIC ")
+	case classPrivate:
+		out.Print("PRIVATE ")
+	}
+
+	if e.Composed {
+		out.Print("COMPOSED ")
+	} else {
+		out.Print("PRIMITIVE ")
+	}
+
+	longFormTag := e.Tag == typeIsLongFormTag
+	if longFormTag {
+		out.Printf("TAG=%d ", e.TagNum)
+	}
+
+	switch {
+	case e.Indefinite:
+		if longFormTag {
+			out.Println("INDEFINITE")
+		} else {
+			out.Println(composedLabel(e), "INDEFINITE")
+		}
+	case longFormTag && e.Composed:
+		out.Print("\n")
+	case longFormTag:
+		printOctets(out, e.Raw)
+		out.Print("\n")
+		hinter.PrintHint(out, e.Raw)
+	default:
+		if err := v.printFixedTag(out, e); err != nil {
+			return false, err
+		}
+	}
+
+	if v.labelFor != nil {
+		if label := v.labelFor(e); label != "" {
+			out.Println("#", label)
+		}
+	}
+
+	descend = descendForPrint(e)
+	if descend {
+		v.stack = append(v.stack, out.NextLevel())
+	}
+	return descend, nil
+}
+
+func (v *textVisitor) Leave(e *Element) error {
+	if descendForPrint(e) {
+		if e.Indefinite {
+			// The AST drops the END-OF-CONTENT marker (it's a terminator, not
+			// data), so it has to be synthesized here to close out the
+			// indented block the same way a fixed-length one closes with
+			// dedent: as a line text2der's isEndOfContent recognizes.
+			v.cur().Print("UNIVERSAL PRIMITIVE ")
+			v.cur().Println("END-OF-CONTENT")
+		}
+		v.stack = v.stack[:len(v.stack)-1]
+	}
+	return nil
+}
+
+// descendForPrint reports whether the text printer recurses into a composed
+// element's children instead of dumping its raw content as hex. This covers
+// indefinite-length values, long-form-tagged composed values, and the fixed
+// universal SET/SEQUENCE tags; every other composed type (EXTERNAL,
+// EMBEDDED-PDV, and unrecognized tags) is printed as raw data instead, as
+// der2text always has.
+func descendForPrint(e *Element) bool {
+	if !e.Composed {
+		return false
+	}
+	if e.Indefinite || e.Tag == typeIsLongFormTag {
+		return true
+	}
+	typeByte := e.Class | composed | e.Tag
+	return typeByte == typeSet|composed || typeByte == typeSequence|composed
+}
+
+// composedLabel returns the text label for a composed element with a fixed
+// (non-long-form) tag, for use when it has an indefinite length.
+func composedLabel(e *Element) string {
+	typeByte := e.Class | composed | e.Tag
+	switch typeByte &^ 0xC0 {
+	case typeExternal | composed:
+		return "EXTERNAL"
+	case typeEmbeddedPDV | composed:
+		return "EMBEDDED-PDV"
+	case typeSet | composed:
+		return "SET"
+	case typeSequence | composed:
+		return "SEQUENCE"
+	default:
+		return fmt.Sprintf("UNHANDLED-TAG=%02x", e.Tag)
+	}
+}
+
+// printFixedTag prints the body of an element whose tag is a fixed
+// (non-long-form), definite-length universal/application/context/private
+// tag — i.e. everything der2text knew how to render before BER long-form
+// tags and indefinite lengths were added.
+func (v *textVisitor) printFixedTag(out *indenter.Indenter, e *Element) error {
+	content := e.Raw
+	contentLen := len(content)
+	typeByte := e.Class | boolToComposedBit(e.Composed) | e.Tag
+
+	switch typeByte {
+	case typeEndOfContent | primitive:
+		if contentLen != 0 {
+			return errors.New("End-of-content had unexpected length " + strconv.Itoa(contentLen))
+		}
+		out.Println("END-OF-CONTENT")
+	case typeBoolean | primitive:
+		if contentLen != 1 {
+			return errors.New("Boolean had unexpected length " + strconv.Itoa(contentLen))
+		}
+		if content[0] == byte(0) {
+			out.Println("BOOLEAN FALSE")
+		} else {
+			out.Println("BOOLEAN TRUE")
+		}
+	case typeInteger | primitive:
+		handleInteger("INTEGER", out, content)
+	case typeBitString | primitive:
+		if contentLen < 1 {
+			return errors.New("BitString had no padding byte")
+		}
+		padding := int(content[0])
+		if padding < 0 || padding > 7 {
+			return errors.New("BitString padding has illegal value " + strconv.Itoa(padding))
+		}
+		out.Printf("BITSTRING PAD=%d ", padding)
+		printOctets(out, content[1:])
+		out.Print("\n")
+	case typeOctetString | primitive:
+		handleData("OCTETSTRING", out, content)
+	case typeNull | primitive:
+		if contentLen != 0 {
+			return errors.New("Null has non-zero content")
+		}
+		out.Print("NULL\n")
+	case typeObjectIdentifier | primitive:
+		oid, err := e.AsOID()
+		if err != nil {
+			return errors.New("OID doesn't have content")
+		}
+		out.Println("OID", oid)
+		if oidHint := oids.Name(oid); oidHint != "" {
+			out.Println("#", oidHint)
+		}
+	case typeObjectDescription | primitive:
+		handleData("OBJECTDESCRIPTION", out, content)
+	case typeExternal | composed:
+		handleData("EXTERNAL", out, content)
+	case typeReal | primitive:
+		if err := printReal(out, content); err != nil {
+			return err
+		}
+	case typeEnumerated | primitive:
+		handleInteger("ENUMERATED", out, content)
+	case typeEmbeddedPDV | composed:
+		handleData("EMBEDDED-PDV", out, content)
+	case typeUtf8String | primitive:
+		handleString("UTF8STRING", out, content)
+	case typeRelativeOID | primitive:
+		oid, err := e.AsOID()
+		if err != nil {
+			return errors.New("Relative OID doesn't have content")
+		}
+		out.Println("RELATIVEOID", oid)
+		if oidHint := oids.Name(oid); oidHint != "" {
+			out.Println("#", oidHint)
+		}
+	case typeNumericString | primitive:
+		handleString("NUMERICSTRING", out, content)
+	case typePrintableString | primitive:
+		handleString("PRINTABLESTRING", out, content)
+	case typeSet | composed:
+		out.Println("SET")
+	case typeSequence | composed:
+		out.Println("SEQUENCE")
+	case typeT61String | primitive:
+		handleData("T61STRING", out, content)
+	case typeVideotexString | primitive:
+		handleData("VIDEOTEXSTRING", out, content)
+	case typeIA5String | primitive:
+		handleString("IA5STRING", out, content)
+	case typeUTCTime | primitive:
+		handleData("UTCTIME", out, content)
+		if len(content) == 13 && content[12] == 'Z' {
+			out.Printf("# 20%s-%s-%s %s:%s:%s GMT\n",
+				content[0:2], content[2:4], content[4:6], content[6:8], content[8:10], content[10:12])
+		} else if len(content) == 11 && content[10] == 'Z' {
+			out.Printf("# 20%s-%s-%s %s:%s:00 GMT\n",
+				content[0:2], content[2:4], content[4:6], content[6:8], content[8:10])
+		}
+	case typeGeneralizedTime | primitive:
+		handleString("GENERALIZEDTIME", out, content)
+	case typeGraphicString | primitive:
+		handleData("GRAPHICSTRING", out, content)
+	case typeVisibleString | primitive:
+		handleString("VISIBLESTRING", out, content)
+	case typeGeneralString | primitive:
+		handleData("GENERALSTRING", out, content)
+	case typeUniversalString | primitive:
+		b, err := utf32ToUtf8(content)
+		if err != nil {
+			return err
+		}
+		handleString("UNIVERSALSTRING", out, b)
+	case typeCharacterString | primitive:
+		handleData("CHARACTERSTRING", out, content)
+	case typeBMPString | primitive:
+		b, err := utf16ToUtf8(content)
+		if err != nil {
+			return err
+		}
+		handleString("BMPSTRING", out, b)
+	default:
+		label := fmt.Sprintf("UNHANDLED-TAG=%02x", e.Tag)
+		handleData(label, out, content)
+	}
+	return nil
+}
+
+func boolToComposedBit(isComposed bool) byte {
+	if isComposed {
+		return composed
+	}
+	return primitive
+}
+
+func printString(out *indenter.Indenter, content []byte) {
+	for _, v := range content {
+		if v == '\n' {
+			out.Print("\\n")
+		} else if v == '\r' {
+			out.Print("\\r")
+		} else {
+			out.Write([]byte{v})
+		}
+	}
+}
+
+func handleData(label string, out *indenter.Indenter, content []byte) {
+	out.Printf("%s ", label)
+	printOctets(out, content)
+	out.Print("\n")
+	hinter.PrintHint(out, content)
+}
+
+func handleString(label string, out *indenter.Indenter, content []byte) {
+	out.Printf("%s ", label)
+	printString(out, content)
+	out.Print("\n")
+}
+
+func handleInteger(label string, out *indenter.Indenter, content []byte) {
+	if len(content) > 0 && len(content) <= 8 && content[0]&0x80 == 0 {
+		// An 8-byte positive value still fits in int64 (its top bit is 0,
+		// so it's under 2^63); conveniently display it.
+		value := int64(0)
+		if content[0]&0x80 == 0 {
+			// positive number
+			for _, v := range content {
+				value *= 256
+				value += int64(v)
+			}
+		}
+		out.Println(label, value)
+	} else if len(content) > 8 || len(content) == 0 || content[0]&0x80 != 0 {
+		// just dump it in hex
+		handleData(label, out, content)
+	}
+}
+
+// printReal prints a REAL's value per X.690 §8.5: the special values, the
+// ISO 6093 decimal (NR1/NR2/NR3) form, and the binary form (sign, base,
+// scale, exponent, mantissa). The binary form is printed as a plain decimal
+// value when it converts cleanly; otherwise it falls back to a structured
+// "REAL BINARY ..." line that text2der can still round-trip exactly.
+func printReal(out *indenter.Indenter, content []byte) error {
+	if len(content) == 0 {
+		out.Println("REAL", "0")
+		return nil
+	}
+
+	first := content[0]
+	if first&0xC0 == 0x40 {
+		switch first {
+		case 0x40:
+			out.Println("REAL", "PLUS-INFINITY")
+		case 0x41:
+			out.Println("REAL", "MINUS-INFINITY")
+		case 0x42:
+			out.Println("REAL", "NaN")
+		case 0x43:
+			out.Println("REAL", "MINUS-ZERO")
+		default:
+			return fmt.Errorf("REAL has unrecognized special value %#x", first)
+		}
+		return nil
+	}
+
+	if first&0x80 == 0 {
+		// Decimal (NR1/NR2/NR3) form: the remaining bytes are already a
+		// human-readable ISO 6093 number, so print them as-is.
+		out.Println("REAL", string(content[1:]))
+		return nil
+	}
+
+	negative := first&0x40 != 0
+	var base int64
+	switch (first >> 4) & 0x3 {
+	case 0:
+		base = 2
+	case 1:
+		base = 8
+	case 2:
+		base = 16
+	default:
+		return errors.New("REAL binary encoding has reserved base")
+	}
+	scale := uint((first >> 2) & 0x3)
+
+	rest := content[1:]
+	var expLen int
+	switch first & 0x3 {
+	case 0, 1, 2:
+		expLen = int(first&0x3) + 1
+	default:
+		if len(rest) < 1 {
+			return errors.New("REAL binary encoding is missing its exponent-length byte")
+		}
+		expLen = int(rest[0])
+		rest = rest[1:]
+	}
+	if len(rest) < expLen || expLen == 0 {
+		return errors.New("REAL binary encoding's exponent is truncated")
+	}
+	exp := twosComplementBigInt(rest[:expLen])
+	mantissa := new(big.Int).SetBytes(rest[expLen:])
+	if mantissa.Sign() == 0 {
+		return errors.New("REAL binary encoding has no mantissa")
+	}
+
+	if f, ok := realBinaryToFloat64(negative, mantissa, scale, base, exp); ok {
+		out.Println("REAL", strconv.FormatFloat(f, 'g', -1, 64))
+		return nil
+	}
+
+	sign := "+"
+	if negative {
+		sign = "-"
+	}
+	out.Printf("REAL BINARY SIGN=%s BASE=%d SCALE=%d EXP=%s MANTISSA=%s\n",
+		sign, base, scale, exp.String(), mantissa.String())
+	return nil
+}
+
+// realBinaryToFloat64 computes sign * mantissa * 2^scale * base^exp as a
+// float64, reporting ok=false when the exponent is too large to evaluate or
+// the conversion to float64 wouldn't be exact — in which case the BINARY
+// fallback line is what gets printed, to keep the round trip lossless.
+func realBinaryToFloat64(negative bool, mantissa *big.Int, scale uint, base int64, exp *big.Int) (float64, bool) {
+	if !exp.IsInt64() {
+		return 0, false
+	}
+	e := exp.Int64()
+	if e > 1000 || e < -1000 || mantissa.BitLen() > 200 {
+		return 0, false
+	}
+
+	const prec = 200
+	value := new(big.Float).SetPrec(prec).SetInt(mantissa)
+	value.Mul(value, new(big.Float).SetPrec(prec).SetInt64(1<<scale))
+
+	power := new(big.Float).SetPrec(prec).SetInt64(1)
+	b := new(big.Float).SetPrec(prec).SetInt64(base)
+	n := e
+	if n < 0 {
+		n = -n
+	}
+	for i := int64(0); i < n; i++ {
+		power.Mul(power, b)
+	}
+	if e < 0 {
+		value.Quo(value, power)
+	} else {
+		value.Mul(value, power)
+	}
+	if negative {
+		value.Neg(value)
+	}
+
+	f, acc := value.Float64()
+	if acc != big.Exact || f == 0 {
+		return 0, false
+	}
+	return f, true
+}
+
+func printOctets(out *indenter.Indenter, content []byte) {
+	out.Print(":")
+	for _, v := range content {
+		out.Printf("%02X", v)
+	}
+}
+
+func utf16ToUtf8(input []byte) ([]byte, error) {
+	decoder := unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder()
+	return decoder.Bytes(input)
+}
+
+func utf32ToUtf8(input []byte) ([]byte, error) {
+	decoder := utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM).NewDecoder()
+	return decoder.Bytes(input)
+}
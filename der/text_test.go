@@ -0,0 +1,70 @@
+package der
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/syncsynchalt/der2text/indenter"
+	"github.com/syncsynchalt/der2text/test"
+)
+
+func realToText(t *testing.T, content []byte) (string, error) {
+	var buf bytes.Buffer
+	out := indenter.New(&buf)
+	err := printReal(out, content)
+	return buf.String(), err
+}
+
+func TestPrintRealDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"empty is zero", []byte{}, "REAL 0\n"},
+		{"plus infinity", []byte{0x40}, "REAL PLUS-INFINITY\n"},
+		{"minus infinity", []byte{0x41}, "REAL MINUS-INFINITY\n"},
+		{"not a number", []byte{0x42}, "REAL NaN\n"},
+		{"minus zero", []byte{0x43}, "REAL MINUS-ZERO\n"},
+		{"decimal NR1", []byte{0x01, '1', '2', '3'}, "REAL 123\n"},
+		{"decimal NR3", append([]byte{0x03}, []byte("1.5E2")...), "REAL 1.5E2\n"},
+		// 80 FF 03: base 2, scale 0, 1-byte exponent -1, mantissa 3 -> 1.5
+		{"binary base 2", []byte{0x80, 0xFF, 0x03}, "REAL 1.5\n"},
+		// 90 01 01: base 8, scale 0, 1-byte exponent 1, mantissa 1 -> 8
+		{"binary base 8", []byte{0x90, 0x01, 0x01}, "REAL 8\n"},
+		// E0 00 02: negative, base 16, scale 0, 1-byte exponent 0, mantissa 2 -> -2
+		{"binary base 16 negative", []byte{0xE0, 0x00, 0x02}, "REAL -2\n"},
+		// 8C 00 03: base 2, scale 3, 1-byte exponent 0, mantissa 3 -> 3 * 2^3 = 24
+		{"binary scale", []byte{0x8C, 0x00, 0x03}, "REAL 24\n"},
+		// 83 01 02 05: explicit 1-byte exponent length, exponent 2, mantissa 5 -> 5 * 2^2 = 20
+		{"binary explicit exponent length", []byte{0x83, 0x01, 0x02, 0x05}, "REAL 20\n"},
+		// 81 07 D0 01: 2-byte exponent 2000, mantissa 1; too large to evaluate, falls back to BINARY
+		{"binary exponent too large falls back", []byte{0x81, 0x07, 0xD0, 0x01}, "REAL BINARY SIGN=+ BASE=2 SCALE=0 EXP=2000 MANTISSA=1\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := realToText(t, tc.content)
+			test.Ok(t, err)
+			test.Equals(t, tc.want, got)
+		})
+	}
+}
+
+func TestPrintRealErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content []byte
+	}{
+		{"unrecognized special value", []byte{0x44}},
+		{"reserved base", []byte{0xB0}},
+		{"missing exponent-length byte", []byte{0x83}},
+		{"truncated exponent", []byte{0x81, 0x05}},
+		{"missing mantissa", []byte{0x80, 0x00}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := realToText(t, tc.content)
+			if err == nil {
+				t.Fatalf("expected error for content %x, got none", tc.content)
+			}
+		})
+	}
+}
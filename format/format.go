@@ -0,0 +1,128 @@
+// Package format renders a decoded der.Element tree as JSON or YAML, for
+// pipelines that want to diff certificates or filter fields with jq instead
+// of scraping der2text's indented text.
+package format
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/oids"
+	"gopkg.in/yaml.v3"
+)
+
+// node is the serializable shape of a der.Element: one node per element,
+// typed leaves carrying a decoded "value" (and sometimes "name"), composed
+// elements carrying "children" instead.
+type node struct {
+	Class    string `json:"class" yaml:"class"`
+	Tag      string `json:"tag" yaml:"tag"`
+	Composed bool   `json:"composed,omitempty" yaml:"composed,omitempty"`
+
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Pad   *int   `json:"pad,omitempty" yaml:"pad,omitempty"`
+
+	Children []*node `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// WriteJSON writes elements to w as a JSON array, one object per top-level
+// element.
+func WriteJSON(w io.Writer, elements []*der.Element) error {
+	nodes, err := toNodes(elements)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(nodes)
+}
+
+// WriteYAML writes elements to w as a YAML sequence, one document item per
+// top-level element.
+func WriteYAML(w io.Writer, elements []*der.Element) error {
+	nodes, err := toNodes(elements)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(nodes)
+}
+
+func toNodes(elements []*der.Element) ([]*node, error) {
+	nodes := make([]*node, 0, len(elements))
+	for _, e := range elements {
+		n, err := toNode(e)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func toNode(e *der.Element) (*node, error) {
+	n := &node{
+		Class:    e.ClassName(),
+		Tag:      e.TagName(),
+		Composed: e.Composed,
+	}
+	if e.Composed {
+		children, err := toNodes(e.Children)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = children
+		return n, nil
+	}
+
+	switch n.Tag {
+	case "BOOLEAN":
+		n.Value = boolValue(e.Raw)
+	case "NULL":
+		// no value
+	case "INTEGER", "ENUMERATED":
+		v, err := e.AsInteger()
+		if err != nil {
+			return nil, err
+		}
+		n.Value = v.String()
+	case "OID", "RELATIVEOID":
+		oid, err := e.AsOID()
+		if err != nil {
+			return nil, err
+		}
+		n.Value = oid
+		n.Name = oids.Name(oid)
+	case "BITSTRING":
+		if len(e.Raw) < 1 {
+			return nil, errors.New("format: BITSTRING has no padding byte")
+		}
+		pad := int(e.Raw[0])
+		n.Pad = &pad
+		n.Value = base64.StdEncoding.EncodeToString(e.Raw[1:])
+	case "UTF8STRING", "NUMERICSTRING", "PRINTABLESTRING", "IA5STRING",
+		"VISIBLESTRING", "GENERALIZEDTIME", "UNIVERSALSTRING", "BMPSTRING":
+		s, err := e.AsUTF8()
+		if err != nil {
+			return nil, err
+		}
+		n.Value = s
+	default:
+		// OCTETSTRING and every other raw-dumped type (EXTERNAL, REAL,
+		// UTCTIME, T61STRING, and so on)
+		n.Value = base64.StdEncoding.EncodeToString(e.Raw)
+	}
+	return n, nil
+}
+
+func boolValue(raw []byte) string {
+	if len(raw) == 1 && raw[0] != 0 {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,46 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/test"
+)
+
+func TestWriteJSON(t *testing.T) {
+	// SEQUENCE { INTEGER 5 }
+	elements, err := der.Decode([]byte{0x30, 0x03, 0x02, 0x01, 0x05})
+	test.Ok(t, err)
+
+	var buf bytes.Buffer
+	test.Ok(t, WriteJSON(&buf, elements))
+
+	const want = `[
+  {
+    "class": "universal",
+    "tag": "SEQUENCE",
+    "composed": true,
+    "children": [
+      {
+        "class": "universal",
+        "tag": "INTEGER",
+        "value": "5"
+      }
+    ]
+  }
+]
+`
+	test.Equals(t, want, buf.String())
+}
+
+func TestWriteYAML(t *testing.T) {
+	elements, err := der.Decode([]byte{0x02, 0x01, 0x05})
+	test.Ok(t, err)
+
+	var buf bytes.Buffer
+	test.Ok(t, WriteYAML(&buf, elements))
+
+	const want = "- class: universal\n  tag: INTEGER\n  value: \"5\"\n"
+	test.Equals(t, want, buf.String())
+}
@@ -0,0 +1,3 @@
+package oids
+
+//go:generate go run ./internal/gentrie
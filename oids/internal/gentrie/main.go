@@ -0,0 +1,206 @@
+// Command gentrie reads the OID registry at oids/registry.tsv and writes
+// oids/trie_gen.go: the registry packed into a compact trie (one node per
+// OID arc, each self-length-prefixed so a lookup can skip a sibling's
+// subtree without decoding it) plus the entry table and alias index that
+// the trie's entry indices point into.
+//
+// Run via `go generate ./...` from the oids package, or directly with
+// `go run ./internal/gentrie` from the same directory.
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type registryEntry struct {
+	oid         string
+	arcs        []uint64
+	name        string
+	description string
+	source      string
+	rfc         string
+	aliases     []string
+}
+
+type trieNode struct {
+	arc      uint64
+	entryIdx int // -1 if this node has no entry of its own
+	children map[uint64]*trieNode
+}
+
+func newTrieNode(arc uint64) *trieNode {
+	return &trieNode{arc: arc, entryIdx: -1, children: map[uint64]*trieNode{}}
+}
+
+func (n *trieNode) sortedChildren() []*trieNode {
+	out := make([]*trieNode, 0, len(n.children))
+	for _, c := range n.children {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].arc < out[j].arc })
+	return out
+}
+
+func main() {
+	entries, err := readRegistry("registry.tsv")
+	if err != nil {
+		log.Fatalf("gentrie: %v", err)
+	}
+
+	roots := map[uint64]*trieNode{}
+	for i, e := range entries {
+		cur := roots
+		var node *trieNode
+		for depth, arc := range e.arcs {
+			n, ok := cur[arc]
+			if !ok {
+				n = newTrieNode(arc)
+				cur[arc] = n
+			}
+			node = n
+			if depth < len(e.arcs)-1 {
+				cur = n.children
+			}
+		}
+		node.entryIdx = i
+	}
+	rootList := make([]*trieNode, 0, len(roots))
+	for _, n := range roots {
+		rootList = append(rootList, n)
+	}
+	sort.Slice(rootList, func(i, j int) bool { return rootList[i].arc < rootList[j].arc })
+
+	trieBytes := encodeForest(rootList)
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, header)
+	fmt.Fprintf(&buf, "var trieBytes = []byte(%q)\n\n", string(trieBytes))
+
+	fmt.Fprint(&buf, "var entryTable = []Entry{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "\t{OID: %q, Name: %q, Description: %q, Source: %q, RFC: %q},\n",
+			e.oid, e.name, e.description, e.source, e.rfc)
+	}
+	fmt.Fprint(&buf, "}\n\n")
+
+	fmt.Fprint(&buf, "var oidByAlias = map[string]string{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", e.name, e.oid)
+		for _, a := range e.aliases {
+			fmt.Fprintf(&buf, "\t%q: %q,\n", a, e.oid)
+		}
+	}
+	fmt.Fprint(&buf, "}\n")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("gentrie: %v", err)
+	}
+	if err := os.WriteFile("trie_gen.go", formatted, 0o644); err != nil {
+		log.Fatalf("gentrie: %v", err)
+	}
+}
+
+const header = `// Code generated by gentrie from registry.tsv. DO NOT EDIT.
+
+package oids
+
+`
+
+// readRegistry parses the tab-separated registry file: OID, Name,
+// Description, Source, RFC, and an optional comma-separated Aliases column.
+func readRegistry(path string) ([]registryEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("%s: no data rows", path)
+	}
+	var entries []registryEntry
+	for _, line := range lines[1:] { // skip header row
+		if line == "" {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		for len(cols) < 6 {
+			cols = append(cols, "")
+		}
+		arcs, err := parseArcs(cols[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		var aliases []string
+		if cols[5] != "" {
+			aliases = strings.Split(cols[5], ",")
+		}
+		entries = append(entries, registryEntry{
+			oid:         cols[0],
+			arcs:        arcs,
+			name:        cols[1],
+			description: cols[2],
+			source:      cols[3],
+			rfc:         cols[4],
+			aliases:     aliases,
+		})
+	}
+	return entries, nil
+}
+
+func parseArcs(oid string) ([]uint64, error) {
+	parts := strings.Split(oid, ".")
+	arcs := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad OID arc %q in %q: %w", p, oid, err)
+		}
+		arcs[i] = n
+	}
+	return arcs, nil
+}
+
+// encodeForest serializes the top-level arcs of the trie: a child count
+// followed by that many encoded nodes. There's no wrapping root node since
+// no real OID has a zero-length arc path.
+func encodeForest(roots []*trieNode) []byte {
+	var out []byte
+	out = appendUvarint(out, uint64(len(roots)))
+	for _, r := range roots {
+		out = append(out, encodeNode(r)...)
+	}
+	return out
+}
+
+// encodeNode serializes n as: a varint byte length (so a lookup can skip
+// this node's whole subtree without decoding it), then the arc, the entry
+// index plus one (0 meaning "no entry here"), the child count, and the
+// children in turn.
+func encodeNode(n *trieNode) []byte {
+	var payload []byte
+	payload = appendUvarint(payload, n.arc)
+	payload = appendUvarint(payload, uint64(n.entryIdx+1))
+	children := n.sortedChildren()
+	payload = appendUvarint(payload, uint64(len(children)))
+	for _, c := range children {
+		payload = append(payload, encodeNode(c)...)
+	}
+
+	out := appendUvarint(nil, uint64(len(payload)))
+	return append(out, payload...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(b, tmp[:n]...)
+}
@@ -0,0 +1,88 @@
+// Package oids looks up names for OIDs (and OIDs for names) against a
+// registry of common X.509, PKIX, and CMS/PKCS object identifiers. The
+// registry is packed at build time (see gen.go) into a compact trie keyed
+// by OID arc, which stays far smaller than a map[string]string as the
+// registry grows since shared prefixes (e.g. every 1.2.840.113549.1.*
+// PKCS arc) are stored once.
+package oids
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Entry is one row of the OID registry.
+type Entry struct {
+	OID         string
+	Name        string
+	Description string
+	Source      string
+	RFC         string
+}
+
+// Name returns the registered name for oid, or "" if oid isn't known.
+func Name(oid string) string {
+	e, ok := lookup(oid)
+	if !ok {
+		return ""
+	}
+	return e.Name
+}
+
+// OID returns the dotted-decimal OID registered under name, which may be
+// an entry's primary name or one of its short-name aliases (e.g. "CN" for
+// commonName). It returns "" if name isn't known.
+func OID(name string) string {
+	return oidByAlias[name]
+}
+
+// Info returns the full registry entry for oid, and whether it was found.
+func Info(oid string) (Entry, bool) {
+	return lookup(oid)
+}
+
+// Search returns every registry entry whose OID is prefix or lies under
+// it (e.g. Search("1.2.840.113549.1.9") returns the PKCS#9 attributes),
+// in trie order. It returns nil if prefix isn't a well-formed OID or
+// matches nothing.
+func Search(prefix string) []Entry {
+	arcs, err := parseArcs(prefix)
+	if err != nil {
+		return nil
+	}
+	var out []Entry
+	trieWalk(trieBytes, arcs, func(entryIdx int) {
+		out = append(out, entryTable[entryIdx])
+	})
+	return out
+}
+
+func lookup(oid string) (Entry, bool) {
+	arcs, err := parseArcs(oid)
+	if err != nil {
+		return Entry{}, false
+	}
+	idx, ok := trieLookup(trieBytes, arcs)
+	if !ok {
+		return Entry{}, false
+	}
+	return entryTable[idx], true
+}
+
+func parseArcs(oid string) ([]uint64, error) {
+	if oid == "" {
+		return nil, errors.New("oids: empty OID")
+	}
+	parts := strings.Split(oid, ".")
+	arcs := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("oids: bad OID arc %q: %w", p, err)
+		}
+		arcs[i] = n
+	}
+	return arcs, nil
+}
@@ -0,0 +1,37 @@
+package oids
+
+import (
+	"testing"
+
+	"github.com/syncsynchalt/der2text/test"
+)
+
+func TestNameAndOID(t *testing.T) {
+	test.Equals(t, "commonName", Name("2.5.4.3"))
+	test.Equals(t, "sha256WithRSAEncryption", Name("1.2.840.113549.1.1.11"))
+	test.Equals(t, "", Name("9.9.9"))
+
+	test.Equals(t, "2.5.4.3", OID("CN"))
+	test.Equals(t, "2.5.4.3", OID("commonName"))
+	test.Equals(t, "1.2.840.113549.1.1.11", OID("sha256WithRSAEncryption"))
+	test.Equals(t, "", OID("notAnOID"))
+}
+
+func TestInfo(t *testing.T) {
+	e, ok := Info("2.5.29.19")
+	test.Equals(t, true, ok)
+	test.Equals(t, "basicConstraints", e.Name)
+	test.Equals(t, "X.509", e.Source)
+
+	_, ok = Info("1.2.3.4.5.6")
+	test.Equals(t, false, ok)
+}
+
+func TestSearch(t *testing.T) {
+	got := Search("1.2.840.113549.1.9")
+	test.Equals(t, 8, len(got))
+	test.Equals(t, "1.2.840.113549.1.9.1", got[0].OID)
+
+	test.Equals(t, 0, len(Search("9.9.9")))
+	test.Equals(t, 0, len(Search("not-an-oid")))
+}
@@ -0,0 +1,94 @@
+package oids
+
+import "encoding/binary"
+
+// The trie generated into trieBytes by gentrie (see
+// internal/gentrie/main.go) has no wrapping root node: it's a varint child
+// count followed by that many nodes. Each node is a varint byte length
+// (letting a lookup skip the whole subtree of a sibling that doesn't
+// match), then a varint arc, a varint entry index plus one (0 = no entry
+// at this node), a varint child count, and that many child nodes in turn.
+
+func readUvarint(b []byte) (uint64, []byte) {
+	v, n := binary.Uvarint(b)
+	return v, b[n:]
+}
+
+// trieLookup returns the entry index for the exact arc path arcs, walking
+// one arc at a time and skipping past non-matching siblings via their
+// length prefix rather than decoding them.
+func trieLookup(data []byte, arcs []uint64) (int, bool) {
+	numChildren, data := readUvarint(data)
+	for level, want := range arcs {
+		arc, entryPlus1, childCount, child, after, ok := findChild(data, numChildren, want)
+		if !ok {
+			return 0, false
+		}
+		_ = arc
+		if level == len(arcs)-1 {
+			if entryPlus1 == 0 {
+				return 0, false
+			}
+			return int(entryPlus1 - 1), true
+		}
+		data, numChildren = child, childCount
+		_ = after
+	}
+	return 0, false
+}
+
+// trieWalk calls fn with the entry index of every entry in the subtree
+// rooted at prefix, including an entry at the prefix node itself if one
+// exists.
+func trieWalk(data []byte, prefix []uint64, fn func(entryIdx int)) {
+	numChildren, data := readUvarint(data)
+	selfEntry := -1
+	for level, want := range prefix {
+		_, entryPlus1, childCount, child, _, ok := findChild(data, numChildren, want)
+		if !ok {
+			return
+		}
+		if level == len(prefix)-1 && entryPlus1 != 0 {
+			selfEntry = int(entryPlus1 - 1)
+		}
+		data, numChildren = child, childCount
+	}
+	if selfEntry >= 0 {
+		fn(selfEntry)
+	}
+	walkAll(data, numChildren, fn)
+}
+
+func walkAll(data []byte, numChildren uint64, fn func(entryIdx int)) {
+	for i := uint64(0); i < numChildren; i++ {
+		nodeLen, rest := readUvarint(data)
+		node, after := rest[:nodeLen], rest[nodeLen:]
+		_, node = readUvarint(node) // arc: not needed, only used for matching during descent
+		entryPlus1, node := readUvarint(node)
+		childCount, node := readUvarint(node)
+		if entryPlus1 != 0 {
+			fn(int(entryPlus1 - 1))
+		}
+		walkAll(node, childCount, fn)
+		data = after
+	}
+}
+
+// findChild scans numChildren sibling nodes encoded at data for the one
+// whose arc equals want, returning its decoded fields plus the bytes that
+// follow it (after) for callers that need to keep scanning.
+func findChild(data []byte, numChildren uint64, want uint64) (arc, entryPlus1, childCount uint64, children, after []byte, ok bool) {
+	for i := uint64(0); i < numChildren; i++ {
+		nodeLen, rest := readUvarint(data)
+		node, following := rest[:nodeLen], rest[nodeLen:]
+		arc, node = readUvarint(node)
+		if arc != want {
+			data = following
+			continue
+		}
+		entryPlus1, node = readUvarint(node)
+		childCount, node = readUvarint(node)
+		return arc, entryPlus1, childCount, node, following, true
+	}
+	return 0, 0, 0, nil, nil, false
+}
@@ -0,0 +1,226 @@
+// Code generated by gentrie from registry.tsv. DO NOT EDIT.
+
+package oids
+
+var trieBytes = []byte("\x02\x81\x03\x01\x00\x02\x83\x02\x02\x00\x01\xfe\x01\xc8\x06\x00\x03\x10\xb8N\x00\x01\v\x04\x00\x02\x03\x01Y\x00\x03\x03Z\x000\xbdN\x00\x03\a\x02\x00\x01\x03\x01Q\x00\v\x03\x00\x01\a\x01\x00\x01\x03\aV\x00\x17\x04\x00\x02\x03\x01R\x00\x0f\x03\x00\x03\x03\x02S\x00\x03\x03T\x00\x03\x04U\x00\xb6\x01\x8d\xf7\x06\x00\x02\xa7\x01\x01\x00\x053\x01\x00\f\x03\x01)\x00\x03\x02*\x00\x03\x04+\x00\x03\x05,\x00\x03\a-\x00\x03\b.\x00\x03\t/\x00\x03\n0\x00\x03\v1\x00\x03\f2\x00\x03\r3\x00\x03\x0e4\x00\v\x05\x00\x02\x03\f5\x00\x03\r6\x00\x1b\a\x00\x06\x03\x017\x00\x03\x028\x00\x03\x039\x00\x03\x04:\x00\x03\x05;\x00\x03\x06<\x00#\t\x00\b\x03\x01=\x00\x03\x03>\x00\x03\x04?\x00\x03\x05@\x00\x03\x06A\x00\x03\aB\x00\x03\x0eC\x00\x03\x0fD\x00#\f\x00\x01\x1f\n\x00\x01\x1b\x01\x00\x06\x03\x01E\x00\x03\x02F\x00\x03\x03G\x00\x03\x04H\x00\x03\x05I\x00\x03\x06J\x00\a\x02\x00\x01\x03\x05K\x00x\x03\x00\x03S\x06\x00\x01O\x01\x00\x01K\x05\x00\x01G\x05\x00\x01C\a\x00\x04\v\x01\x00\x02\x03\x01\\\x00\x03\v]\x00\v\x02\x00\x02\x03\x01^\x00\x03\x02_\x00\x1b\x03\x00\x06\x03\x01`\x00\x03\x02a\x00\x03\x03b\x00\x03\x04c\x00\x03\bd\x00\x03\te\x00\v0\x00\x02\x03\x01f\x00\x03\x02g\x00\x0f\x0e\x00\x01\v\x03\x00\x01\a\x02\x00\x01\x03\x1aL\x00\x10\x84\x01\x00\x01\v\x00\x00\x02\x03\"W\x00\x03#X\x00\xe5\x01\x02\x00\x02\xab\x01\x05\x00\x02K\x04\x00\x12\x03\x03\x01\x00\x03\x04\x02\x00\x03\x05\x03\x00\x03\x06\x04\x00\x03\a\x05\x00\x03\b\x06\x00\x03\t\a\x00\x03\n\b\x00\x03\v\t\x00\x03\f\n\x00\x03\r\v\x00\x03\x11\f\x00\x03)\r\x00\x03*\x0e\x00\x03+\x0f\x00\x03,\x10\x00\x03.\x11\x00\x03A\x12\x00[\x1d\x00\x16\x03\x0e\x13\x00\x03\x0f\x14\x00\x03\x10\x15\x00\x03\x11\x16\x00\x03\x12\x17\x00\x03\x13\x18\x00\x03\x14\x19\x00\x03\x15\x1a\x00\x03\x17\x1b\x00\x03\x18\x1c\x00\x03\x1b\x1d\x00\x03\x1c\x1e\x00\x03\x1d\x1f\x00\x03\x1e \x00\x03\x1f!\x00\x03 \"\x00\x03!#\x00\x03#$\x00\x03$%\x00\x03%&\x00\x03.'\x00\x036(\x004\x10\x00\x010\xc8\x06\x00\x01+\x01\x00\x01'e\x00\x01#\x03\x00\x01\x1f\x04\x00\x02\x13\x02\x00\x04\x03\x01M\x00\x03\x02N\x00\x03\x03O\x00\x03\x04P\x00\a\x03\x00\x01\x03\x02[\x00")
+
+var entryTable = []Entry{
+	{OID: "2.5.4.3", Name: "commonName", Description: "common name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.4", Name: "surname", Description: "surname attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.5", Name: "serialNumber", Description: "serial number attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.6", Name: "countryName", Description: "country name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.7", Name: "localityName", Description: "locality name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.8", Name: "stateOrProvinceName", Description: "state or province name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.9", Name: "streetAddress", Description: "street address attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.10", Name: "organizationName", Description: "organization name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.11", Name: "organizationalUnitName", Description: "organizational unit name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.12", Name: "title", Description: "title attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.13", Name: "description", Description: "description attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.17", Name: "postalCode", Description: "postal code attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.41", Name: "name", Description: "name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.42", Name: "givenName", Description: "given name attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.43", Name: "initials", Description: "initials attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.44", Name: "generationQualifier", Description: "generation qualifier attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.46", Name: "dnQualifier", Description: "distinguished name qualifier attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.4.65", Name: "pseudonym", Description: "pseudonym attribute", Source: "X.520", RFC: ""},
+	{OID: "2.5.29.14", Name: "subjectKeyIdentifier", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.15", Name: "keyUsage", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.16", Name: "privateKeyUsagePeriod", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.17", Name: "subjectAltName", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.18", Name: "issuerAltName", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.19", Name: "basicConstraints", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.20", Name: "cRLNumber", Description: "CRL extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.21", Name: "cRLReason", Description: "CRL entry extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.23", Name: "holdInstructionCode", Description: "CRL entry extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.24", Name: "invalidityDate", Description: "CRL entry extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.27", Name: "deltaCRLIndicator", Description: "CRL extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.28", Name: "issuingDistributionPoint", Description: "CRL extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.29", Name: "certificateIssuer", Description: "CRL entry extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.30", Name: "nameConstraints", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.31", Name: "cRLDistributionPoints", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.32", Name: "certificatePolicies", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.33", Name: "policyMappings", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.35", Name: "authorityKeyIdentifier", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.36", Name: "policyConstraints", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.37", Name: "extKeyUsage", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.46", Name: "freshestCRL", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "2.5.29.54", Name: "inhibitAnyPolicy", Description: "certificate extension", Source: "X.509", RFC: "5280"},
+	{OID: "1.2.840.113549.1.1.1", Name: "rsaEncryption", Description: "RSA encryption", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.2", Name: "md2WithRSAEncryption", Description: "RSA signature with MD2", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.4", Name: "md5WithRSAEncryption", Description: "RSA signature with MD5", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.5", Name: "sha1WithRSAEncryption", Description: "RSA signature with SHA-1", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.7", Name: "rsaesOaep", Description: "RSAES-OAEP encryption scheme", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.8", Name: "mgf1", Description: "mask generation function 1", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.9", Name: "pSpecified", Description: "OAEP PSource option", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.10", Name: "rsassaPss", Description: "RSASSA-PSS signature scheme", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.11", Name: "sha256WithRSAEncryption", Description: "RSA signature with SHA-256", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.12", Name: "sha384WithRSAEncryption", Description: "RSA signature with SHA-384", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.13", Name: "sha512WithRSAEncryption", Description: "RSA signature with SHA-512", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.1.14", Name: "sha224WithRSAEncryption", Description: "RSA signature with SHA-224", Source: "PKCS1", RFC: "8017"},
+	{OID: "1.2.840.113549.1.5.12", Name: "PBKDF2", Description: "password-based key derivation function 2", Source: "PKCS5", RFC: "8018"},
+	{OID: "1.2.840.113549.1.5.13", Name: "PBES2", Description: "password-based encryption scheme 2", Source: "PKCS5", RFC: "8018"},
+	{OID: "1.2.840.113549.1.7.1", Name: "data", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.7.2", Name: "signedData", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.7.3", Name: "envelopedData", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.7.4", Name: "signedAndEnvelopedData", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.7.5", Name: "digestedData", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.7.6", Name: "encryptedData", Description: "CMS content type", Source: "PKCS7", RFC: "5652"},
+	{OID: "1.2.840.113549.1.9.1", Name: "emailAddress", Description: "email address attribute", Source: "PKCS9", RFC: "2985"},
+	{OID: "1.2.840.113549.1.9.3", Name: "contentType", Description: "CMS attribute", Source: "PKCS9", RFC: "5652"},
+	{OID: "1.2.840.113549.1.9.4", Name: "messageDigest", Description: "CMS attribute", Source: "PKCS9", RFC: "5652"},
+	{OID: "1.2.840.113549.1.9.5", Name: "signingTime", Description: "CMS attribute", Source: "PKCS9", RFC: "5652"},
+	{OID: "1.2.840.113549.1.9.6", Name: "counterSignature", Description: "CMS attribute", Source: "PKCS9", RFC: "5652"},
+	{OID: "1.2.840.113549.1.9.7", Name: "challengePassword", Description: "CSR attribute", Source: "PKCS9", RFC: "2985"},
+	{OID: "1.2.840.113549.1.9.14", Name: "extensionRequest", Description: "CSR attribute", Source: "PKCS9", RFC: "2985"},
+	{OID: "1.2.840.113549.1.9.15", Name: "smimeCapabilities", Description: "S/MIME attribute", Source: "PKCS9", RFC: "2633"},
+	{OID: "1.2.840.113549.1.12.10.1.1", Name: "keyBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.1.12.10.1.2", Name: "pkcs8ShroudedKeyBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.1.12.10.1.3", Name: "certBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.1.12.10.1.4", Name: "crlBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.1.12.10.1.5", Name: "secretBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.1.12.10.1.6", Name: "safeContentsBag", Description: "PKCS#12 bag type", Source: "PKCS12", RFC: ""},
+	{OID: "1.2.840.113549.2.5", Name: "md5", Description: "MD5 digest algorithm", Source: "RSADSI", RFC: "1319"},
+	{OID: "1.3.14.3.2.26", Name: "sha1", Description: "SHA-1 digest algorithm", Source: "OIW", RFC: "3174"},
+	{OID: "2.16.840.1.101.3.4.2.1", Name: "sha256", Description: "SHA-256 digest algorithm", Source: "NIST", RFC: "6234"},
+	{OID: "2.16.840.1.101.3.4.2.2", Name: "sha384", Description: "SHA-384 digest algorithm", Source: "NIST", RFC: "6234"},
+	{OID: "2.16.840.1.101.3.4.2.3", Name: "sha512", Description: "SHA-512 digest algorithm", Source: "NIST", RFC: "6234"},
+	{OID: "2.16.840.1.101.3.4.2.4", Name: "sha224", Description: "SHA-224 digest algorithm", Source: "NIST", RFC: "6234"},
+	{OID: "1.2.840.10045.2.1", Name: "id-ecPublicKey", Description: "elliptic curve public key", Source: "ANSI-X9.62", RFC: "5480"},
+	{OID: "1.2.840.10045.4.1", Name: "ecdsa-with-SHA1", Description: "ECDSA signature with SHA-1", Source: "ANSI-X9.62", RFC: "5758"},
+	{OID: "1.2.840.10045.4.3.2", Name: "ecdsa-with-SHA256", Description: "ECDSA signature with SHA-256", Source: "ANSI-X9.62", RFC: "5758"},
+	{OID: "1.2.840.10045.4.3.3", Name: "ecdsa-with-SHA384", Description: "ECDSA signature with SHA-384", Source: "ANSI-X9.62", RFC: "5758"},
+	{OID: "1.2.840.10045.4.3.4", Name: "ecdsa-with-SHA512", Description: "ECDSA signature with SHA-512", Source: "ANSI-X9.62", RFC: "5758"},
+	{OID: "1.2.840.10045.3.1.7", Name: "prime256v1", Description: "NIST P-256 curve", Source: "SEC2", RFC: "5480"},
+	{OID: "1.3.132.0.34", Name: "secp384r1", Description: "NIST P-384 curve", Source: "SEC2", RFC: "5480"},
+	{OID: "1.3.132.0.35", Name: "secp521r1", Description: "NIST P-521 curve", Source: "SEC2", RFC: "5480"},
+	{OID: "1.2.840.10040.4.1", Name: "dsa", Description: "DSA public key", Source: "X9.57", RFC: "3279"},
+	{OID: "1.2.840.10040.4.3", Name: "dsa-with-sha1", Description: "DSA signature with SHA-1", Source: "X9.57", RFC: "3279"},
+	{OID: "2.16.840.1.101.3.4.3.2", Name: "dsa-with-sha256", Description: "DSA signature with SHA-256", Source: "NIST", RFC: "5758"},
+	{OID: "1.3.6.1.5.5.7.1.1", Name: "authorityInfoAccess", Description: "certificate extension", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.1.11", Name: "subjectInfoAccess", Description: "certificate extension", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.2.1", Name: "id-qt-cps", Description: "certification practice statement qualifier", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.2.2", Name: "id-qt-unotice", Description: "user notice qualifier", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.1", Name: "serverAuth", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.2", Name: "clientAuth", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.3", Name: "codeSigning", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.4", Name: "emailProtection", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.8", Name: "timeStamping", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.3.9", Name: "OCSPSigning", Description: "extended key usage purpose", Source: "PKIX", RFC: "5280"},
+	{OID: "1.3.6.1.5.5.7.48.1", Name: "ocsp", Description: "authority info access method", Source: "PKIX", RFC: "6960"},
+	{OID: "1.3.6.1.5.5.7.48.2", Name: "caIssuers", Description: "authority info access method", Source: "PKIX", RFC: "5280"},
+}
+
+var oidByAlias = map[string]string{
+	"commonName":               "2.5.4.3",
+	"CN":                       "2.5.4.3",
+	"surname":                  "2.5.4.4",
+	"SN":                       "2.5.4.4",
+	"serialNumber":             "2.5.4.5",
+	"countryName":              "2.5.4.6",
+	"C":                        "2.5.4.6",
+	"localityName":             "2.5.4.7",
+	"L":                        "2.5.4.7",
+	"stateOrProvinceName":      "2.5.4.8",
+	"ST":                       "2.5.4.8",
+	"streetAddress":            "2.5.4.9",
+	"STREET":                   "2.5.4.9",
+	"organizationName":         "2.5.4.10",
+	"O":                        "2.5.4.10",
+	"organizationalUnitName":   "2.5.4.11",
+	"OU":                       "2.5.4.11",
+	"title":                    "2.5.4.12",
+	"description":              "2.5.4.13",
+	"postalCode":               "2.5.4.17",
+	"name":                     "2.5.4.41",
+	"givenName":                "2.5.4.42",
+	"GN":                       "2.5.4.42",
+	"initials":                 "2.5.4.43",
+	"generationQualifier":      "2.5.4.44",
+	"dnQualifier":              "2.5.4.46",
+	"pseudonym":                "2.5.4.65",
+	"subjectKeyIdentifier":     "2.5.29.14",
+	"keyUsage":                 "2.5.29.15",
+	"privateKeyUsagePeriod":    "2.5.29.16",
+	"subjectAltName":           "2.5.29.17",
+	"issuerAltName":            "2.5.29.18",
+	"basicConstraints":         "2.5.29.19",
+	"cRLNumber":                "2.5.29.20",
+	"cRLReason":                "2.5.29.21",
+	"holdInstructionCode":      "2.5.29.23",
+	"invalidityDate":           "2.5.29.24",
+	"deltaCRLIndicator":        "2.5.29.27",
+	"issuingDistributionPoint": "2.5.29.28",
+	"certificateIssuer":        "2.5.29.29",
+	"nameConstraints":          "2.5.29.30",
+	"cRLDistributionPoints":    "2.5.29.31",
+	"certificatePolicies":      "2.5.29.32",
+	"policyMappings":           "2.5.29.33",
+	"authorityKeyIdentifier":   "2.5.29.35",
+	"policyConstraints":        "2.5.29.36",
+	"extKeyUsage":              "2.5.29.37",
+	"freshestCRL":              "2.5.29.46",
+	"inhibitAnyPolicy":         "2.5.29.54",
+	"rsaEncryption":            "1.2.840.113549.1.1.1",
+	"md2WithRSAEncryption":     "1.2.840.113549.1.1.2",
+	"md5WithRSAEncryption":     "1.2.840.113549.1.1.4",
+	"sha1WithRSAEncryption":    "1.2.840.113549.1.1.5",
+	"rsaesOaep":                "1.2.840.113549.1.1.7",
+	"mgf1":                     "1.2.840.113549.1.1.8",
+	"pSpecified":               "1.2.840.113549.1.1.9",
+	"rsassaPss":                "1.2.840.113549.1.1.10",
+	"sha256WithRSAEncryption":  "1.2.840.113549.1.1.11",
+	"sha384WithRSAEncryption":  "1.2.840.113549.1.1.12",
+	"sha512WithRSAEncryption":  "1.2.840.113549.1.1.13",
+	"sha224WithRSAEncryption":  "1.2.840.113549.1.1.14",
+	"PBKDF2":                   "1.2.840.113549.1.5.12",
+	"PBES2":                    "1.2.840.113549.1.5.13",
+	"data":                     "1.2.840.113549.1.7.1",
+	"signedData":               "1.2.840.113549.1.7.2",
+	"envelopedData":            "1.2.840.113549.1.7.3",
+	"signedAndEnvelopedData":   "1.2.840.113549.1.7.4",
+	"digestedData":             "1.2.840.113549.1.7.5",
+	"encryptedData":            "1.2.840.113549.1.7.6",
+	"emailAddress":             "1.2.840.113549.1.9.1",
+	"contentType":              "1.2.840.113549.1.9.3",
+	"messageDigest":            "1.2.840.113549.1.9.4",
+	"signingTime":              "1.2.840.113549.1.9.5",
+	"counterSignature":         "1.2.840.113549.1.9.6",
+	"challengePassword":        "1.2.840.113549.1.9.7",
+	"extensionRequest":         "1.2.840.113549.1.9.14",
+	"smimeCapabilities":        "1.2.840.113549.1.9.15",
+	"keyBag":                   "1.2.840.113549.1.12.10.1.1",
+	"pkcs8ShroudedKeyBag":      "1.2.840.113549.1.12.10.1.2",
+	"certBag":                  "1.2.840.113549.1.12.10.1.3",
+	"crlBag":                   "1.2.840.113549.1.12.10.1.4",
+	"secretBag":                "1.2.840.113549.1.12.10.1.5",
+	"safeContentsBag":          "1.2.840.113549.1.12.10.1.6",
+	"md5":                      "1.2.840.113549.2.5",
+	"sha1":                     "1.3.14.3.2.26",
+	"sha256":                   "2.16.840.1.101.3.4.2.1",
+	"sha384":                   "2.16.840.1.101.3.4.2.2",
+	"sha512":                   "2.16.840.1.101.3.4.2.3",
+	"sha224":                   "2.16.840.1.101.3.4.2.4",
+	"id-ecPublicKey":           "1.2.840.10045.2.1",
+	"ecdsa-with-SHA1":          "1.2.840.10045.4.1",
+	"ecdsa-with-SHA256":        "1.2.840.10045.4.3.2",
+	"ecdsa-with-SHA384":        "1.2.840.10045.4.3.3",
+	"ecdsa-with-SHA512":        "1.2.840.10045.4.3.4",
+	"prime256v1":               "1.2.840.10045.3.1.7",
+	"secp384r1":                "1.3.132.0.34",
+	"secp521r1":                "1.3.132.0.35",
+	"dsa":                      "1.2.840.10040.4.1",
+	"dsa-with-sha1":            "1.2.840.10040.4.3",
+	"dsa-with-sha256":          "2.16.840.1.101.3.4.3.2",
+	"authorityInfoAccess":      "1.3.6.1.5.5.7.1.1",
+	"subjectInfoAccess":        "1.3.6.1.5.5.7.1.11",
+	"id-qt-cps":                "1.3.6.1.5.5.7.2.1",
+	"id-qt-unotice":            "1.3.6.1.5.5.7.2.2",
+	"serverAuth":               "1.3.6.1.5.5.7.3.1",
+	"clientAuth":               "1.3.6.1.5.5.7.3.2",
+	"codeSigning":              "1.3.6.1.5.5.7.3.3",
+	"emailProtection":          "1.3.6.1.5.5.7.3.4",
+	"timeStamping":             "1.3.6.1.5.5.7.3.8",
+	"OCSPSigning":              "1.3.6.1.5.5.7.3.9",
+	"ocsp":                     "1.3.6.1.5.5.7.48.1",
+	"caIssuers":                "1.3.6.1.5.5.7.48.2",
+}
@@ -0,0 +1,664 @@
+// Package text2der reverses der2text's indented grammar back into DER bytes.
+// It reads exactly the text produced by der.Parse and re-encodes each
+// element, so the two halves form a round trip: der2text, edit the text by
+// hand, text2der.
+package text2der
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/syncsynchalt/der2text/oids"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+const (
+	classUniversal       = 0 << 6
+	classApplication     = 1 << 6
+	classContextSpecific = 2 << 6
+	classPrivate         = 3 << 6
+
+	composed  = 1 << 5
+	primitive = 0 << 5
+
+	typeEndOfContent  = 0x0
+	typeIsLongFormTag = 0x1F
+)
+
+// fixedTagByLabel maps the label der's text printer emits for a fixed
+// (non-long-form) universal tag back to that tag's numeric value, so
+// marshalElement can rebuild the header byte it was printed from.
+var fixedTagByLabel = map[string]byte{
+	"END-OF-CONTENT":    0x00,
+	"BOOLEAN":           0x01,
+	"INTEGER":           0x02,
+	"BITSTRING":         0x03,
+	"OCTETSTRING":       0x04,
+	"NULL":              0x05,
+	"OID":               0x06,
+	"OBJECTDESCRIPTION": 0x07,
+	"EXTERNAL":          0x08,
+	"REAL":              0x09,
+	"ENUMERATED":        0x0A,
+	"EMBEDDED-PDV":      0x0B,
+	"UTF8STRING":        0x0C,
+	"RELATIVEOID":       0x0D,
+	"SEQUENCE":          0x10,
+	"SET":               0x11,
+	"NUMERICSTRING":     0x12,
+	"PRINTABLESTRING":   0x13,
+	"T61STRING":         0x14,
+	"VIDEOTEXSTRING":    0x15,
+	"IA5STRING":         0x16,
+	"UTCTIME":           0x17,
+	"GENERALIZEDTIME":   0x18,
+	"GRAPHICSTRING":     0x19,
+	"VISIBLESTRING":     0x1A,
+	"GENERALSTRING":     0x1B,
+	"UNIVERSALSTRING":   0x1C,
+	"CHARACTERSTRING":   0x1D,
+	"BMPSTRING":         0x1E,
+}
+
+// line is one non-comment row of the grammar, with its indentation depth
+// (two spaces per nesting level, matching indenter.NextLevel) stripped off
+// and recorded separately.
+type line struct {
+	depth int
+	text  string
+}
+
+// Marshal reads the indented grammar produced by der.Parse and returns the
+// DER (or BER, for elements that came from long-form tags or indefinite
+// lengths) bytes it describes.
+func Marshal(r io.Reader) ([]byte, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	out, rest, err := marshalElements(lines, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("text2der: unexpected indentation before %q", rest[0].text)
+	}
+	return out, nil
+}
+
+func readLines(r io.Reader) ([]line, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	var lines []line
+	for scanner.Scan() {
+		raw := scanner.Text()
+		spaces := 0
+		for spaces < len(raw) && raw[spaces] == ' ' {
+			spaces++
+		}
+		text := raw[spaces:]
+		if text == "" || strings.HasPrefix(text, "#") {
+			// blank lines and "# hint" comments aren't part of the grammar
+			continue
+		}
+		if spaces%2 != 0 {
+			return nil, fmt.Errorf("text2der: odd indentation (%d spaces) before %q", spaces, text)
+		}
+		lines = append(lines, line{depth: spaces / 2, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// marshalElements encodes every element at the given depth in order,
+// stopping at the first line that isn't at that depth (a dedent) or at the
+// end of input.
+func marshalElements(lines []line, depth int) (out []byte, rest []line, err error) {
+	for len(lines) > 0 && lines[0].depth == depth {
+		var elem []byte
+		elem, lines, err = marshalElement(lines, depth)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, elem...)
+	}
+	return out, lines, nil
+}
+
+func marshalElement(lines []line, depth int) (encoded []byte, rest []line, err error) {
+	hdr := lines[0].text
+	rest = lines[1:]
+
+	classByte, hdr, err := takeClass(hdr)
+	if err != nil {
+		return nil, nil, err
+	}
+	composedByte, hdr, err := takeComposed(hdr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tagByte, tagNum, hdr, err := takeTag(hdr)
+	if err != nil {
+		return nil, nil, err
+	}
+	typeByte := classByte | composedByte | tagByte
+
+	indefinite := false
+	if rem := strings.TrimSuffix(hdr, "INDEFINITE"); rem != hdr {
+		indefinite = true
+		hdr = strings.TrimSpace(rem)
+	}
+
+	var content []byte
+	if tagByte == typeIsLongFormTag {
+		if composedByte == composed {
+			if indefinite {
+				content, rest, err = marshalIndefiniteContent(rest, depth+1)
+			} else {
+				content, rest, err = marshalElements(rest, depth+1)
+			}
+		} else {
+			content, err = parseOctets(hdr)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		head, err := encodeHeader(typeByte, tagNum, len(content), indefinite)
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(head, content...), rest, nil
+	}
+
+	label, value, ok := splitLabel(hdr)
+	if !ok {
+		return nil, nil, fmt.Errorf("text2der: couldn't parse element header %q", hdr)
+	}
+
+	if fixed, known := fixedTagByLabel[label]; known {
+		tagByte = fixed
+	} else if strings.HasPrefix(label, "UNHANDLED-TAG=") {
+		n, perr := strconv.ParseUint(label[len("UNHANDLED-TAG="):], 16, 8)
+		if perr != nil {
+			return nil, nil, fmt.Errorf("text2der: bad %q: %w", label, perr)
+		}
+		tagByte = byte(n)
+	}
+	typeByte = classByte | composedByte | tagByte
+
+	// A composed element with an indefinite length always has its children
+	// printed and indented (der's printer descends into every INDEFINITE
+	// composed element regardless of tag, not just SEQUENCE/SET — see
+	// descendForPrint), so it's marshalled the same way here regardless of
+	// label: SEQUENCE, SET, EXTERNAL, EMBEDDED-PDV, or an UNHANDLED-TAG.
+	if composedByte == composed && indefinite {
+		content, rest, err = marshalIndefiniteContent(rest, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		head, err := encodeHeader(typeByte, tagNum, len(content), indefinite)
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(head, content...), rest, nil
+	}
+
+	switch label {
+	case "SEQUENCE", "SET":
+		content, rest, err = marshalElements(rest, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+	case "END-OF-CONTENT":
+		content = nil
+	case "BOOLEAN":
+		if value == "TRUE" {
+			content = []byte{0xFF}
+		} else {
+			content = []byte{0x00}
+		}
+	case "NULL":
+		content = nil
+	case "INTEGER", "ENUMERATED":
+		content, err = marshalInteger(value)
+	case "BITSTRING":
+		content, err = marshalBitString(value)
+	case "OID":
+		content, err = marshalOID(value)
+	case "RELATIVEOID":
+		content, err = marshalRelativeOID(value)
+	case "UTF8STRING", "NUMERICSTRING", "PRINTABLESTRING", "IA5STRING",
+		"VISIBLESTRING", "GENERALIZEDTIME":
+		content = unescapeString(value)
+	case "UNIVERSALSTRING":
+		content, err = utf8ToUtf32(unescapeString(value))
+	case "BMPSTRING":
+		content, err = utf8ToUtf16(unescapeString(value))
+	case "REAL":
+		content, err = marshalReal(value)
+	case "OCTETSTRING", "OBJECTDESCRIPTION", "EXTERNAL", "EMBEDDED-PDV",
+		"T61STRING", "VIDEOTEXSTRING", "UTCTIME", "GRAPHICSTRING",
+		"GENERALSTRING", "CHARACTERSTRING":
+		content, err = parseOctets(value)
+	default:
+		if strings.HasPrefix(label, "UNHANDLED-TAG=") {
+			content, err = parseOctets(value)
+		} else {
+			return nil, nil, fmt.Errorf("text2der: unrecognized element %q", label)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	head, err := encodeHeader(typeByte, tagNum, len(content), indefinite)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(head, content...), rest, nil
+}
+
+// marshalIndefiniteContent consumes elements until it finds the
+// END-OF-CONTENT line that closes an indefinite-length value, and returns
+// the encoded children followed by the 00 00 terminator.
+func marshalIndefiniteContent(lines []line, depth int) (content []byte, rest []line, err error) {
+	for {
+		if len(lines) == 0 || lines[0].depth != depth {
+			return nil, nil, errors.New("text2der: missing END-OF-CONTENT for indefinite-length value")
+		}
+		if isEndOfContent(lines[0].text) {
+			content = append(content, typeEndOfContent|primitive, 0x00)
+			return content, lines[1:], nil
+		}
+		var elem []byte
+		elem, lines, err = marshalElement(lines, depth)
+		if err != nil {
+			return nil, nil, err
+		}
+		content = append(content, elem...)
+	}
+}
+
+func isEndOfContent(hdr string) bool {
+	_, hdr, err := takeClass(hdr)
+	if err != nil {
+		return false
+	}
+	_, hdr, err = takeComposed(hdr)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(hdr) == "END-OF-CONTENT"
+}
+
+func takeClass(hdr string) (byte, string, error) {
+	for prefix, class := range map[string]byte{
+		"UNIVERSAL ":        classUniversal,
+		"APPLICATION ":      classApplication,
+		"CONTEXT-SPECIFIC ": classContextSpecific,
+		"PRIVATE ":          classPrivate,
+	} {
+		if strings.HasPrefix(hdr, prefix) {
+			return class, hdr[len(prefix):], nil
+		}
+	}
+	return 0, "", fmt.Errorf("text2der: missing class in %q", hdr)
+}
+
+func takeComposed(hdr string) (byte, string, error) {
+	switch {
+	case strings.HasPrefix(hdr, "PRIMITIVE "):
+		return primitive, hdr[len("PRIMITIVE "):], nil
+	case strings.HasPrefix(hdr, "COMPOSED "):
+		return composed, hdr[len("COMPOSED "):], nil
+	}
+	return 0, "", fmt.Errorf("text2der: missing PRIMITIVE/COMPOSED in %q", hdr)
+}
+
+func takeTag(hdr string) (tagByte byte, tagNum int, rest string, err error) {
+	if strings.HasPrefix(hdr, "TAG=") {
+		hdr = hdr[len("TAG="):]
+		end := strings.IndexByte(hdr, ' ')
+		if end < 0 {
+			end = len(hdr)
+		}
+		n, err := strconv.Atoi(hdr[:end])
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("text2der: bad long-form tag number: %w", err)
+		}
+		rest = strings.TrimPrefix(hdr[end:], " ")
+		return typeIsLongFormTag, n, rest, nil
+	}
+	return 0, 0, hdr, nil
+}
+
+// splitLabel pulls the leading keyword (e.g. "INTEGER") off a header and
+// returns the remainder as its value, with surrounding space trimmed.
+func splitLabel(hdr string) (label, value string, ok bool) {
+	hdr = strings.TrimRight(hdr, " ")
+	idx := strings.IndexByte(hdr, ' ')
+	if idx < 0 {
+		return hdr, "", hdr != ""
+	}
+	return hdr[:idx], strings.TrimLeft(hdr[idx:], " "), true
+}
+
+func encodeHeader(typeByte byte, tagNum int, contentLen int, indefinite bool) ([]byte, error) {
+	var head []byte
+	if typeByte&0x1F == typeIsLongFormTag {
+		head = append(head, typeByte)
+		head = append(head, encodeLongFormTag(tagNum)...)
+	} else {
+		head = append(head, typeByte)
+	}
+	if indefinite {
+		return append(head, 0x80), nil
+	}
+	return append(head, encodeLength(contentLen)...), nil
+}
+
+func encodeLongFormTag(tagNum int) []byte {
+	if tagNum == 0 {
+		return []byte{0x00}
+	}
+	var digits []byte
+	for tagNum > 0 {
+		digits = append([]byte{byte(tagNum & 0x7f)}, digits...)
+		tagNum >>= 7
+	}
+	for i := 0; i < len(digits)-1; i++ {
+		digits[i] |= 0x80
+	}
+	return digits
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte(n & 0xff)}, digits...)
+		n >>= 8
+	}
+	return append([]byte{byte(0x80 | len(digits))}, digits...)
+}
+
+func marshalInteger(value string) ([]byte, error) {
+	if strings.HasPrefix(value, ":") {
+		return parseOctets(value)
+	}
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("text2der: bad integer %q", value)
+	}
+	return encodeMinimalInt(n), nil
+}
+
+// marshalReal reverses der's printReal: the special values and the binary
+// form's "REAL BINARY ..." fallback are parsed field by field, while the
+// decimal (NR1/NR2/NR3) form is already the ISO 6093 text der wants, so it's
+// re-tagged with a format byte and emitted as-is.
+func marshalReal(value string) ([]byte, error) {
+	switch value {
+	case "0":
+		return nil, nil
+	case "PLUS-INFINITY":
+		return []byte{0x40}, nil
+	case "MINUS-INFINITY":
+		return []byte{0x41}, nil
+	case "NaN":
+		return []byte{0x42}, nil
+	case "MINUS-ZERO":
+		return []byte{0x43}, nil
+	}
+	if strings.HasPrefix(value, "BINARY ") {
+		return marshalBinaryReal(strings.TrimPrefix(value, "BINARY "))
+	}
+
+	formatByte := byte(1)
+	switch {
+	case strings.ContainsAny(value, "eE"):
+		formatByte = 3
+	case strings.ContainsAny(value, ".,"):
+		formatByte = 2
+	}
+	return append([]byte{formatByte}, []byte(value)...), nil
+}
+
+// marshalBinaryReal parses the "SIGN=- BASE=2 SCALE=0 EXP=10 MANTISSA=123"
+// fields printed for a REAL whose binary encoding didn't convert cleanly to
+// a plain decimal value, and re-encodes them per X.690 §8.5.
+func marshalBinaryReal(fields string) ([]byte, error) {
+	vals := map[string]string{}
+	for _, f := range strings.Fields(fields) {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("text2der: bad REAL BINARY field %q", f)
+		}
+		vals[kv[0]] = kv[1]
+	}
+
+	base, err := strconv.Atoi(vals["BASE"])
+	if err != nil {
+		return nil, fmt.Errorf("text2der: bad REAL BASE %q", vals["BASE"])
+	}
+	var baseCode byte
+	switch base {
+	case 2:
+		baseCode = 0
+	case 8:
+		baseCode = 1
+	case 16:
+		baseCode = 2
+	default:
+		return nil, fmt.Errorf("text2der: unsupported REAL base %d", base)
+	}
+
+	scale, err := strconv.Atoi(vals["SCALE"])
+	if err != nil || scale < 0 || scale > 3 {
+		return nil, fmt.Errorf("text2der: bad REAL SCALE %q", vals["SCALE"])
+	}
+	exp, ok := new(big.Int).SetString(vals["EXP"], 10)
+	if !ok {
+		return nil, fmt.Errorf("text2der: bad REAL EXP %q", vals["EXP"])
+	}
+	mantissa, ok := new(big.Int).SetString(vals["MANTISSA"], 10)
+	if !ok || mantissa.Sign() < 0 {
+		return nil, fmt.Errorf("text2der: bad REAL MANTISSA %q", vals["MANTISSA"])
+	}
+
+	first := byte(0x80) | baseCode<<4 | byte(scale)<<2
+	if vals["SIGN"] == "-" {
+		first |= 0x40
+	}
+
+	var expHead []byte
+	expBytes := encodeMinimalInt(exp)
+	switch len(expBytes) {
+	case 1:
+		first |= 0
+	case 2:
+		first |= 1
+	case 3:
+		first |= 2
+	default:
+		first |= 3
+		expHead = []byte{byte(len(expBytes))}
+	}
+
+	content := append([]byte{first}, expHead...)
+	content = append(content, expBytes...)
+	content = append(content, mantissa.Bytes()...)
+	return content, nil
+}
+
+// encodeMinimalInt returns the shortest two's-complement big-endian
+// encoding of n, as required for DER INTEGER content.
+func encodeMinimalInt(n *big.Int) []byte {
+	if n.Sign() == 0 {
+		return []byte{0x00}
+	}
+	if n.Sign() > 0 {
+		b := n.Bytes()
+		if b[0]&0x80 != 0 {
+			b = append([]byte{0x00}, b...)
+		}
+		return b
+	}
+
+	// Find the smallest byte count k such that n fits in the signed range
+	// [-2^(8k-1), 2^(8k-1)-1], then encode n's residue mod 2^(8k).
+	k := 1
+	for {
+		low := new(big.Int).Lsh(big.NewInt(-1), uint(8*k-1))
+		if n.Cmp(low) >= 0 {
+			break
+		}
+		k++
+	}
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(8*k))
+	val := new(big.Int).Add(mod, n)
+	b := val.Bytes()
+	for len(b) < k {
+		b = append([]byte{0x00}, b...)
+	}
+	return b
+}
+
+func marshalBitString(value string) ([]byte, error) {
+	const prefix = "PAD="
+	if !strings.HasPrefix(value, prefix) {
+		return nil, fmt.Errorf("text2der: bad bitstring %q", value)
+	}
+	value = value[len(prefix):]
+	sp := strings.IndexByte(value, ' ')
+	if sp < 0 {
+		return nil, fmt.Errorf("text2der: bitstring missing octets %q", value)
+	}
+	pad, err := strconv.Atoi(value[:sp])
+	if err != nil {
+		return nil, fmt.Errorf("text2der: bad bitstring padding: %w", err)
+	}
+	octets, err := parseOctets(strings.TrimLeft(value[sp:], " "))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(pad)}, octets...), nil
+}
+
+// marshalOID turns an OID value into its DER content. Besides a
+// dotted-decimal OID, value may be a registered name or short-name alias
+// (e.g. "CN" or "sha256WithRSAEncryption") from the oids package, letting
+// hand-edited text use the same names der2text's "#" hint comments show.
+func marshalOID(value string) ([]byte, error) {
+	if resolved := oids.OID(value); resolved != "" {
+		value = resolved
+	}
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("text2der: OID needs at least two arcs: %q", value)
+	}
+	arcs := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("text2der: bad OID arc %q: %w", p, err)
+		}
+		arcs[i] = n
+	}
+	content := []byte{byte(arcs[0]*40 + arcs[1])}
+	for _, arc := range arcs[2:] {
+		content = append(content, encodeBase128(arc)...)
+	}
+	return content, nil
+}
+
+func marshalRelativeOID(value string) ([]byte, error) {
+	parts := strings.Split(value, ".")
+	var content []byte
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("text2der: bad relative OID arc %q: %w", p, err)
+		}
+		content = append(content, encodeBase128(n)...)
+	}
+	return content, nil
+}
+
+func encodeBase128(n int) []byte {
+	if n == 0 {
+		return []byte{0x00}
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte(n & 0x7f)}, digits...)
+		n >>= 7
+	}
+	for i := 0; i < len(digits)-1; i++ {
+		digits[i] |= 0x80
+	}
+	return digits
+}
+
+// parseOctets decodes the ":HEXHEX..." form used for raw-dumped content.
+func parseOctets(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, ":") {
+		return nil, fmt.Errorf("text2der: expected \":hex\" octets, got %q", value)
+	}
+	hexStr := value[1:]
+	if len(hexStr)%2 != 0 {
+		return nil, fmt.Errorf("text2der: odd-length hex %q", hexStr)
+	}
+	out := make([]byte, len(hexStr)/2)
+	for i := range out {
+		n, err := strconv.ParseUint(hexStr[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("text2der: bad hex in %q: %w", hexStr, err)
+		}
+		out[i] = byte(n)
+	}
+	return out, nil
+}
+
+// unescapeString reverses printString's "\n"/"\r" escaping; every other byte
+// (including multi-byte UTF-8 sequences) was written through untouched.
+func unescapeString(value string) []byte {
+	var out []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			switch value[i+1] {
+			case 'n':
+				out = append(out, '\n')
+				i++
+				continue
+			case 'r':
+				out = append(out, '\r')
+				i++
+				continue
+			}
+		}
+		out = append(out, value[i])
+	}
+	return out
+}
+
+func utf8ToUtf16(input []byte) ([]byte, error) {
+	encoder := unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder()
+	return encoder.Bytes(input)
+}
+
+func utf8ToUtf32(input []byte) ([]byte, error) {
+	encoder := utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM).NewEncoder()
+	return encoder.Bytes(input)
+}
@@ -0,0 +1,82 @@
+package text2der
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/syncsynchalt/der2text/der"
+	"github.com/syncsynchalt/der2text/indenter"
+	"github.com/syncsynchalt/der2text/test"
+)
+
+func parseToText(t *testing.T, data []byte) string {
+	var buf bytes.Buffer
+	out := indenter.New(&buf)
+	err := der.Parse(out, data)
+	test.Ok(t, err)
+	return buf.String()
+}
+
+// roundTrip checks that parse -> emit -> parse produces identical text,
+// which is the stability guarantee text2der is meant to provide even when
+// the re-encoded bytes aren't byte-for-byte identical to the input (e.g. an
+// indefinite length may be preserved rather than collapsed to definite).
+func roundTrip(t *testing.T, data []byte) {
+	text := parseToText(t, data)
+	reencoded, err := Marshal(strings.NewReader(text))
+	test.Ok(t, err)
+	text2 := parseToText(t, reencoded)
+	test.Equals(t, text, text2)
+}
+
+func TestRoundTripPrimitives(t *testing.T) {
+	roundTrip(t, []byte{0x01, 0x01, 0x00})             // BOOLEAN FALSE
+	roundTrip(t, []byte{0x01, 0x01, 0xFF})             // BOOLEAN TRUE
+	roundTrip(t, []byte{0x02, 0x01, 0x05})             // INTEGER 5
+	roundTrip(t, []byte{0x02, 0x02, 0xFF, 0x01})       // INTEGER -255
+	roundTrip(t, []byte{0x05, 0x00})                   // NULL
+	roundTrip(t, []byte{0x04, 0x03, 0x01, 0x02, 0x03}) // OCTETSTRING
+	roundTrip(t, []byte{0x03, 0x02, 0x00, 0xF0})       // BITSTRING PAD=0
+}
+
+func TestRoundTripOID(t *testing.T) {
+	// 1.2.840.113549.1.1.11 (sha256WithRSAEncryption)
+	roundTrip(t, []byte{0x06, 0x09, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x01, 0x0b})
+}
+
+func TestMarshalOIDAcceptsRegisteredNamesAndAliases(t *testing.T) {
+	want := []byte{0x55, 0x04, 0x03} // 2.5.4.3, commonName
+
+	got, err := marshalOID("2.5.4.3")
+	test.Ok(t, err)
+	test.Equals(t, want, got)
+
+	got, err = marshalOID("commonName")
+	test.Ok(t, err)
+	test.Equals(t, want, got)
+
+	got, err = marshalOID("CN")
+	test.Ok(t, err)
+	test.Equals(t, want, got)
+
+	_, err = marshalOID("notARegisteredOID")
+	if err == nil {
+		t.Fatal("expected error for unknown OID name")
+	}
+}
+
+func TestRoundTripSequence(t *testing.T) {
+	// SEQUENCE { INTEGER 1, INTEGER 2 }
+	roundTrip(t, []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x02})
+}
+
+func TestRoundTripLongForm(t *testing.T) {
+	// CONTEXT-SPECIFIC PRIMITIVE, tag 42, one content byte
+	roundTrip(t, []byte{0x9F, 0x2A, 0x01, 0x07})
+}
+
+func TestRoundTripIndefiniteLength(t *testing.T) {
+	// SEQUENCE INDEFINITE { INTEGER 1 } END-OF-CONTENT
+	roundTrip(t, []byte{0x30, 0x80, 0x02, 0x01, 0x01, 0x00, 0x00})
+}